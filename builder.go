@@ -2,7 +2,10 @@ package bsql
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -11,6 +14,7 @@ const (
 	LeftJoin
 	RightJoin
 	CrossJoin
+	FullJoin
 )
 
 type Nullable interface {
@@ -41,27 +45,46 @@ func (r secRaw) Build() (string, []interface{}) {
 	return r.query, r.args
 }
 
-type SecAND []Builder
+func index(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
 
-func (a SecAND) Build() (string, []interface{}) {
+func buildSec(d Dialect, name, sep string, bs []Builder) (string, []interface{}, error) {
 	b := strings.Builder{}
 	args := make([]interface{}, 0)
 	b.WriteString("(")
 	f := false
-	for _, v := range a {
+	for i, v := range bs {
 		if IsNull(v) {
 			continue
 		}
 		if f {
-			b.WriteString(" AND ")
+			b.WriteString(sep)
 		}
 		f = true
-		q, a := v.Build()
+		q, a, err := clause(d, name, index(i), v)
+		if err != nil {
+			return "", nil, err
+		}
 		b.WriteString(q)
 		args = append(args, a...)
 	}
 	b.WriteString(")")
-	return b.String(), args
+	return b.String(), args, nil
+}
+
+type SecAND []Builder
+
+func (a SecAND) Build() (string, []interface{}) {
+	return must(a.BuildE())
+}
+
+func (a SecAND) BuildE() (string, []interface{}, error) {
+	return a.build(MySQL)
+}
+
+func (a SecAND) build(d Dialect) (string, []interface{}, error) {
+	return buildSec(d, "SecAND", " AND ", a)
 }
 
 func (a SecAND) Null() bool {
@@ -79,24 +102,15 @@ func (a SecAND) Null() bool {
 type SecOR []Builder
 
 func (o SecOR) Build() (string, []interface{}) {
-	b := strings.Builder{}
-	args := make([]interface{}, 0)
-	b.WriteString("(")
-	f := false
-	for _, v := range o {
-		if IsNull(v) {
-			continue
-		}
-		if f {
-			b.WriteString(" OR ")
-		}
-		f = true
-		q, a := v.Build()
-		b.WriteString(q)
-		args = append(args, a...)
-	}
-	b.WriteString(")")
-	return b.String(), args
+	return must(o.BuildE())
+}
+
+func (o SecOR) BuildE() (string, []interface{}, error) {
+	return o.build(MySQL)
+}
+
+func (o SecOR) build(d Dialect) (string, []interface{}, error) {
+	return buildSec(d, "SecOR", " OR ", o)
 }
 
 func (o SecOR) Null() bool {
@@ -118,18 +132,14 @@ func Raw(query string, args ...interface{}) Builder {
 	}
 }
 
+// EQ is col = value, or col IS NULL if value is nil, see NilAsValue.
 func EQ(col string, value interface{}) Builder {
-	return secRaw{
-		query: col + " = ?",
-		args:  []interface{}{value},
-	}
+	return secEQ{col: col, value: value}
 }
 
+// NQ is col != value, or col IS NOT NULL if value is nil, see NilAsValue.
 func NQ(col string, value interface{}) Builder {
-	return secRaw{
-		query: col + " != ?",
-		args:  []interface{}{value},
-	}
+	return secEQ{col: col, not: true, value: value}
 }
 
 func GT(col string, value interface{}) Builder {
@@ -167,153 +177,341 @@ type SecCase struct {
 }
 
 func (c SecCase) Build() (string, []interface{}) {
+	return must(c.BuildE())
+}
+
+func (c SecCase) BuildE() (string, []interface{}, error) {
+	return c.build(MySQL)
+}
+
+func (c SecCase) build(d Dialect) (string, []interface{}, error) {
 	b := strings.Builder{}
 	args := make([]interface{}, 0)
 	b.WriteString("CASE")
 	if c.Case != nil {
-		q, a := c.Case.Build()
+		q, a, err := clause(d, "SecCase", "Case", c.Case)
+		if err != nil {
+			return "", nil, err
+		}
 		b.WriteString(" " + q)
 		args = append(args, a...)
 	}
-	for _, v := range c.When {
+	for i, v := range c.When {
 		b.WriteString(" WHEN ")
-		q, a := v[0].Build()
+		q, a, err := clause(d, "SecCase", "When"+index(i), v[0])
+		if err != nil {
+			return "", nil, err
+		}
 		b.WriteString(q)
 		args = append(args, a...)
 		b.WriteString(" THEN ")
-		q, a = v[1].Build()
+		q, a, err = clause(d, "SecCase", "Then"+index(i), v[1])
+		if err != nil {
+			return "", nil, err
+		}
 		b.WriteString(q)
 		args = append(args, a...)
 	}
 	if c.Else != nil {
 		b.WriteString(" ELSE ")
-		q, a := c.Else.Build()
+		q, a, err := clause(d, "SecCase", "Else", c.Else)
+		if err != nil {
+			return "", nil, err
+		}
 		b.WriteString(q)
 		args = append(args, a...)
 	}
 	b.WriteString(" END")
-	return b.String(), args
+	return b.String(), args, nil
 }
 
-func Func(fn string, builder ...Builder) Builder {
-	raw := secRaw{}
+type secFunc struct {
+	fn      string
+	builder []Builder
+}
 
-	qs := make([]string, len(builder))
-	for i, v := range builder {
-		q, a := v.Build()
+func (f secFunc) Build() (string, []interface{}) {
+	return must(f.BuildE())
+}
+
+func (f secFunc) BuildE() (string, []interface{}, error) {
+	return f.build(MySQL)
+}
+
+func (f secFunc) build(d Dialect) (string, []interface{}, error) {
+	var args []interface{}
+
+	qs := make([]string, len(f.builder))
+	for i, v := range f.builder {
+		q, a, err := clause(d, "Func", f.fn+index(i), v)
+		if err != nil {
+			return "", nil, err
+		}
 		qs[i] = q
-		raw.args = append(raw.args, a...)
+		args = append(args, a...)
 	}
 
-	raw.query = fn + "(" + strings.Join(qs, ",") + ")"
+	return f.fn + "(" + strings.Join(qs, ",") + ")", args, nil
+}
 
-	return raw
+func Func(fn string, builder ...Builder) Builder {
+	return secFunc{
+		fn:      fn,
+		builder: builder,
+	}
 }
 
 type SecComma []Builder
 
 func (c SecComma) Build() (string, []interface{}) {
+	return must(c.BuildE())
+}
+
+func (c SecComma) BuildE() (string, []interface{}, error) {
+	return c.build(MySQL)
+}
+
+func (c SecComma) build(d Dialect) (string, []interface{}, error) {
 	b := strings.Builder{}
 	args := make([]interface{}, 0)
 	for i, v := range c {
 		if i != 0 {
 			b.WriteString(",")
 		}
-		q, a := v.Build()
+		q, a, err := clause(d, "SecComma", index(i), v)
+		if err != nil {
+			return "", nil, err
+		}
 		b.WriteString(q)
 		args = append(args, a...)
 	}
-	return b.String(), args
+	return b.String(), args, nil
 }
 
-func Embed(query string, builder ...Builder) Builder {
-	raw := secRaw{}
+type secEmbed struct {
+	query   string
+	builder []Builder
+}
+
+func (e secEmbed) Build() (string, []interface{}) {
+	return must(e.BuildE())
+}
 
-	if strings.Count(query, "$") != len(builder) {
-		panic("the number of places does not match")
+func (e secEmbed) BuildE() (string, []interface{}, error) {
+	return e.build(MySQL)
+}
+
+func (e secEmbed) build(d Dialect) (string, []interface{}, error) {
+	if strings.Count(e.query, "$") != len(e.builder) {
+		return "", nil, &BuildError{Builder: "Embed", Err: ErrPlaceholderMismatch}
 	}
 
-	end := len(query)
+	var (
+		query string
+		args  []interface{}
+	)
+
+	end := len(e.query)
 	argNum := 0
 	for i := 0; i < end; i++ {
 		lasti := i
-		for i < end && query[i] != '$' {
+		for i < end && e.query[i] != '$' {
 			i++
 		}
 		if i > lasti {
-			raw.query += query[lasti:i]
+			query += e.query[lasti:i]
 		}
 		if i >= end {
 			break
 		}
-		q, a := builder[argNum].Build()
-		raw.query += q
-		raw.args = append(raw.args, a...)
+		q, a, err := clause(d, "Embed", index(argNum), e.builder[argNum])
+		if err != nil {
+			return "", nil, err
+		}
+		query += q
+		args = append(args, a...)
 		argNum++
 	}
 
-	return raw
+	return query, args, nil
 }
 
-func MakeAlias(b Builder, alias string) Builder {
-	q, a := b.Build()
+func Embed(query string, builder ...Builder) Builder {
+	return secEmbed{
+		query:   query,
+		builder: builder,
+	}
+}
+
+type secAlias struct {
+	b     Builder
+	alias string
+}
+
+func (s secAlias) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s secAlias) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s secAlias) build(d Dialect) (string, []interface{}, error) {
+	q, a, err := clause(d, "MakeAlias", s.alias, s.b)
+	if err != nil {
+		return "", nil, err
+	}
 	if strings.ContainsRune(q, ' ') {
 		q = "(" + q + ")"
 	}
-	return secRaw{
-		query: q + " AS " + alias,
-		args:  a,
+	return q + " AS " + s.alias, a, nil
+}
+
+func MakeAlias(b Builder, alias string) Builder {
+	return secAlias{
+		b:     b,
+		alias: alias,
 	}
 }
 
+type secBracket struct {
+	b Builder
+}
+
+func (s secBracket) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s secBracket) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s secBracket) build(d Dialect) (string, []interface{}, error) {
+	q, a, err := clause(d, "Bracket", "", s.b)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + q + ")", a, nil
+}
+
 func Bracket(b Builder) Builder {
-	q, a := b.Build()
-	return secRaw{
-		query: "(" + q + ")",
-		args:  a,
+	return secBracket{b: b}
+}
+
+type secIn struct {
+	col  string
+	not  bool
+	args []interface{}
+}
+
+func (in secIn) Build() (string, []interface{}) {
+	if len(in.args) == 0 {
+		// col IN () is invalid, nothing is in an empty list
+		if in.not {
+			return "1=1", nil
+		}
+		return "1=0", nil
 	}
+	op := " IN (?"
+	if in.not {
+		op = " NOT IN (?"
+	}
+	return in.col + op + strings.Repeat(",?", len(in.args)-1) + ")", in.args
+}
+
+func (in secIn) empty() bool {
+	return len(in.args) == 0
 }
 
+// MakeIn is col IN (args), it is always false if args is empty.
 func MakeIn(col string, args []interface{}) Builder {
-	return secRaw{
-		query: col + " IN (?" + strings.Repeat(",?", len(args)-1) + ")",
-		args:  args,
+	return secIn{
+		col:  col,
+		args: args,
 	}
 }
 
-func MakeJoin(typ int8, t1, t2, on Builder) Builder {
+// MakeNotIn is col NOT IN (args), it is always true if args is empty.
+func MakeNotIn(col string, args []interface{}) Builder {
+	return secIn{
+		col:  col,
+		not:  true,
+		args: args,
+	}
+}
+
+type secJoin struct {
+	typ        int8
+	t1, t2, on Builder
+}
+
+func (j secJoin) Build() (string, []interface{}) {
+	return must(j.BuildE())
+}
+
+func (j secJoin) BuildE() (string, []interface{}, error) {
+	return j.build(MySQL)
+}
+
+func (j secJoin) build(d Dialect) (string, []interface{}, error) {
+	join, ok := joinKeyword(j.typ)
+	if !ok {
+		return "", nil, &BuildError{Builder: "MakeJoin", Err: fmt.Errorf("%w %d", ErrJoinType, j.typ)}
+	}
+	if j.typ == FullJoin && is(d, "mysql") {
+		return "", nil, unsupported("MakeJoin", "FullJoin", d)
+	}
+	join = " " + join + " "
+
 	var args []interface{}
 
-	qt1, a := t1.Build()
+	qt1, a, err := clause(d, "MakeJoin", "T1", j.t1)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
-	qt2, a := t2.Build()
+	qt2, a, err := clause(d, "MakeJoin", "T2", j.t2)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
 	qon := ""
-	if on != nil {
-		qon, a = on.Build()
+	if j.on != nil {
+		qon, a, err = clause(d, "MakeJoin", "On", j.on)
+		if err != nil {
+			return "", nil, err
+		}
 		qon = " ON " + qon
 		args = append(args, a...)
 	}
 
-	join := ""
+	return qt1 + join + qt2 + qon, args, nil
+}
+
+func joinKeyword(typ int8) (string, bool) {
 	switch typ {
 	case InnerJoin:
-		join = " JOIN "
+		return "JOIN", true
 	case LeftJoin:
-		join = " LEFT JOIN "
+		return "LEFT JOIN", true
 	case RightJoin:
-		join = " RIGHT JOIN "
+		return "RIGHT JOIN", true
 	case CrossJoin:
-		join = " CROSS JOIN "
-	default:
-		panic("")
+		return "CROSS JOIN", true
+	case FullJoin:
+		return "FULL OUTER JOIN", true
 	}
+	return "", false
+}
 
-	return secRaw{
-		query: qt1 + join + qt2 + qon,
-		args:  args,
+func MakeJoin(typ int8, t1, t2, on Builder) Builder {
+	return secJoin{
+		typ: typ,
+		t1:  t1,
+		t2:  t2,
+		on:  on,
 	}
 }
 
@@ -337,17 +535,27 @@ func MakeValues(cols []string, values [][]interface{}) (Builder, error) {
 
 	s := "(?" + strings.Repeat(",?", length-1) + ")"
 
-	v := "VALUES "
-	if len(cols) > 0 {
-		v = "(" + strings.Join(cols, ",") + ") VALUES "
-	}
-
-	return secRaw{
-		query: v + s + strings.Repeat(","+s, len(values)-1),
-		args:  args,
+	return secValues{
+		cols:   cols,
+		values: "VALUES " + s + strings.Repeat(","+s, len(values)-1),
+		args:   args,
 	}, nil
 }
 
+// secValues keeps the column list apart, so that the OUTPUT of SQL Server can go between it and the values.
+type secValues struct {
+	cols   []string
+	values string
+	args   []interface{}
+}
+
+func (v secValues) Build() (string, []interface{}) {
+	if len(v.cols) > 0 {
+		return "(" + strings.Join(v.cols, ",") + ") " + v.values, v.args
+	}
+	return v.values, v.args
+}
+
 func MakeSet(cols map[string]interface{}) Builder {
 	set := secRaw{}
 	ss := make([]string, 0, len(cols))
@@ -385,52 +593,120 @@ type SelectRaw struct {
 	Where    Builder
 	GroupBy  Builder
 	Having   Builder
-	OrderBy  Builder
-	Limit    Builder
+	// Window defines the named windows, see NamedWindow, not supported by SQL Server and Oracle.
+	Window  Builder
+	OrderBy Builder
+	Limit   Builder
+	Lock    *Lock
 }
 
 func (s SelectRaw) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s SelectRaw) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s SelectRaw) build(d Dialect) (string, []interface{}, error) {
+	return s.buildAs(d, "SelectRaw", page{})
+}
+
+// page is the pagination of a Select rendered for the dialect, hasLimit tells a limit of 0 from no limit.
+type page struct {
+	limit, offset uint
+	hasLimit      bool
+}
+
+// buildAs builds s as the named builder, pg is rendered for d if s.Limit is nil.
+func (s SelectRaw) buildAs(d Dialect, name string, pg page) (string, []interface{}, error) {
 	args := make([]interface{}, 0)
 
-	fields, a := s.Fields.Build()
+	fields, a, err := clause(d, name, "Fields", s.Fields)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
-	table, a := s.Table.Build()
+	table, a, err := clause(d, name, "Table", s.Table)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
 	where := ""
 	if !IsNull(s.Where) {
-		q, a := s.Where.Build()
+		q, a, err := clause(d, name, "Where", s.Where)
+		if err != nil {
+			return "", nil, err
+		}
 		where = " WHERE " + q
 		args = append(args, a...)
 	}
 
 	groupBy := ""
 	if s.GroupBy != nil {
-		q, a := s.GroupBy.Build()
+		q, a, err := clause(d, name, "GroupBy", s.GroupBy)
+		if err != nil {
+			return "", nil, err
+		}
 		groupBy = " GROUP BY " + q
 		args = append(args, a...)
 	}
 
 	having := ""
 	if s.Having != nil {
-		q, a := s.Having.Build()
+		q, a, err := clause(d, name, "Having", s.Having)
+		if err != nil {
+			return "", nil, err
+		}
 		having = " HAVING " + q
 		args = append(args, a...)
 	}
 
+	window := ""
+	if s.Window != nil {
+		if is(d, "sqlserver", "oracle") {
+			return "", nil, unsupported(name, "Window", d)
+		}
+		q, a, err := clause(d, name, "Window", s.Window)
+		if err != nil {
+			return "", nil, err
+		}
+		window = " WINDOW " + q
+		args = append(args, a...)
+	}
+
 	orderBy := ""
 	if s.OrderBy != nil {
-		q, a := s.OrderBy.Build()
+		q, a, err := clause(d, name, "OrderBy", s.OrderBy)
+		if err != nil {
+			return "", nil, err
+		}
 		orderBy = " ORDER BY " + q
 		args = append(args, a...)
 	}
 
 	limit := ""
 	if s.Limit != nil {
-		q, a := s.Limit.Build()
+		q, a, err := clause(d, name, "Limit", s.Limit)
+		if err != nil {
+			return "", nil, err
+		}
 		limit = " LIMIT " + q
 		args = append(args, a...)
+	} else {
+		orderBy, a = paginate(d, orderBy, pg.limit, pg.offset, pg.hasLimit)
+		args = append(args, a...)
+	}
+
+	lock := ""
+	if s.Lock != nil {
+		q, _, err := clause(d, name, "Lock", *s.Lock)
+		if err != nil {
+			return "", nil, err
+		}
+		lock = " " + q
 	}
 
 	sel := "SELECT "
@@ -438,7 +714,7 @@ func (s SelectRaw) Build() (string, []interface{}) {
 		sel = "SELECT DISTINCT "
 	}
 
-	return sel + fields + " FROM " + table + where + groupBy + having + orderBy + limit, args
+	return sel + fields + " FROM " + table + where + groupBy + having + window + orderBy + limit + lock, args, nil
 }
 
 type Select struct {
@@ -448,127 +724,405 @@ type Select struct {
 	Where    Builder
 	GroupBy  []string
 	Having   Builder
+	Window   []NamedWindow
 	OrderBy  []string
-	Limit    []uint
+	// Limit is {count} or {offset, count}, rendered as LIMIT ?,? on MySQL.
+	Limit []uint
+	// Offset skips rows before Limit, it can't be used with an offset in Limit.
+	Offset uint
+	Lock   *Lock
 }
 
 func (s Select) Build() (string, []interface{}) {
-	fields := Raw("*")
+	return must(s.BuildE())
+}
+
+func (s Select) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s Select) build(d Dialect) (string, []interface{}, error) {
+	fields := "*"
 	if s.Fields != nil {
-		fields = Raw(strings.Join(s.Fields, ","))
+		fields = strings.Join(s.Fields, ",")
 	}
 
-	var groupBy, orderBy, limit Builder
+	var groupBy, window, orderBy, limit Builder
 	if len(s.GroupBy) != 0 {
 		groupBy = Raw(strings.Join(s.GroupBy, ","))
 	}
 
+	if len(s.Window) != 0 {
+		w := make(SecComma, len(s.Window))
+		for i, v := range s.Window {
+			w[i] = v
+		}
+		window = w
+	}
+
 	if s.OrderBy != nil {
 		orderBy = Raw(strings.Join(s.OrderBy, ","))
 	}
 
-	if len(s.Limit) > 0 {
+	var pg page
+	switch {
+	case len(s.Limit) > 1 && s.Offset > 0:
+		return "", nil, &BuildError{Builder: "Select", Clause: "Offset", Err: ErrPage}
+	case is(d, "mysql") && s.Offset == 0:
 		if len(s.Limit) > 1 {
 			limit = Raw("?,?", s.Limit[0], s.Limit[1])
-		} else {
+		} else if len(s.Limit) > 0 {
 			limit = Raw("?", s.Limit[0])
 		}
+	case len(s.Limit) > 1:
+		pg = page{offset: s.Limit[0], limit: s.Limit[1], hasLimit: true}
+	case len(s.Limit) > 0:
+		pg = page{offset: s.Offset, limit: s.Limit[0], hasLimit: true}
+	default:
+		pg = page{offset: s.Offset}
+	}
+
+	cols := Raw(fields)
+	if is(d, "sqlserver") && pg.offset == 0 && pg.hasLimit {
+		cols = Raw("TOP (?) "+fields, pg.limit)
+		pg.hasLimit = false
 	}
 
 	return SelectRaw{
 		Distinct: s.Distinct,
-		Fields:   fields,
+		Fields:   cols,
 		Table:    s.Table,
 		Where:    s.Where,
 		GroupBy:  groupBy,
 		Having:   s.Having,
+		Window:   window,
 		OrderBy:  orderBy,
 		Limit:    limit,
-	}.Build()
+		Lock:     s.Lock,
+	}.buildAs(d, "Select", pg)
+}
+
+// Paginate returns s limited to the page-th page of size rows, pages start at 1.
+func (s Select) Paginate(page, size int) (Select, error) {
+	if page < 1 || size < 1 || MaxPageSize > 0 && size > MaxPageSize || page-1 > math.MaxInt32/size {
+		return s, fmt.Errorf("%w %d of size %d", ErrPage, page, size)
+	}
+	s.Limit = []uint{uint(size)}
+	s.Offset = uint((page - 1) * size)
+	return s, nil
 }
 
 type UnionAll []Select
 
 func (ua UnionAll) Build() (string, []interface{}) {
+	return must(ua.BuildE())
+}
+
+func (ua UnionAll) BuildE() (string, []interface{}, error) {
+	return ua.build(MySQL)
+}
+
+func (ua UnionAll) build(d Dialect) (string, []interface{}, error) {
 	var (
 		sqls    []string
 		allArgs []interface{}
 	)
-	for _, s := range ua {
-		sql, args := s.Build()
+	for i, s := range ua {
+		sql, args, err := clause(d, "UnionAll", index(i), s)
+		if err != nil {
+			return "", nil, err
+		}
 		sqls = append(sqls, sql)
 		allArgs = append(allArgs, args...)
 	}
 
-	return strings.Join(sqls, " UNION ALL "), allArgs
+	return strings.Join(sqls, " UNION ALL "), allArgs, nil
 }
 
+// Update updates Table, which can be joined tables on MySQL, see From.
+// From lists the other tables of the condition on PostgreSQL, SQLite and SQL Server,
+// OrderBy and Limit bound the updated rows on MySQL, Limit is TOP on SQL Server, 0 means no limit.
 type Update struct {
-	Table Builder
-	Set   Builder
-	Where Builder
+	Table     Builder
+	Set       Builder
+	From      Builder
+	Where     Builder
+	OrderBy   []string
+	Limit     uint
+	Returning []string
 }
 
 func (u Update) Build() (string, []interface{}) {
+	return must(u.BuildE())
+}
+
+func (u Update) BuildE() (string, []interface{}, error) {
+	return u.build(MySQL)
+}
+
+func (u Update) build(d Dialect) (string, []interface{}, error) {
+	switch {
+	case isJoin(u.Table) && !is(d, "mysql"):
+		return "", nil, unsupported("Update", "Table", d)
+	case u.From != nil && !is(d, "postgres", "sqlite3", "sqlserver"):
+		return "", nil, unsupported("Update", "From", d)
+	case len(u.OrderBy) > 0 && !is(d, "mysql"):
+		return "", nil, unsupported("Update", "OrderBy", d)
+	case u.Limit > 0 && !is(d, "mysql", "sqlserver"):
+		return "", nil, unsupported("Update", "Limit", d)
+	case len(u.OrderBy) > 0 && isJoin(u.Table):
+		return "", nil, &BuildError{Builder: "Update", Clause: "OrderBy", Err: fmt.Errorf("%w, joined tables can't be ordered", ErrMultiTable)}
+	case u.Limit > 0 && isJoin(u.Table):
+		return "", nil, &BuildError{Builder: "Update", Clause: "Limit", Err: fmt.Errorf("%w, joined tables can't be limited", ErrMultiTable)}
+	}
+
 	args := make([]interface{}, 0)
 
-	table, a := u.Table.Build()
+	top := ""
+	if u.Limit > 0 && is(d, "sqlserver") {
+		top = "TOP (?) "
+		args = append(args, u.Limit)
+	}
+
+	table, a, err := clause(d, "Update", "Table", u.Table)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
 	set := ""
 	if u.Set != nil {
-		q, a := u.Set.Build()
+		q, a, err := clause(d, "Update", "Set", u.Set)
+		if err != nil {
+			return "", nil, err
+		}
 		set = " SET " + q
 		args = append(args, a...)
 	}
 
+	ret, err := returning(d, "Update", "INSERTED", u.Returning)
+	if err != nil {
+		return "", nil, err
+	}
+	if is(d, "sqlserver") {
+		set += ret
+		ret = ""
+	}
+
+	from := ""
+	if u.From != nil {
+		q, a, err := clause(d, "Update", "From", u.From)
+		if err != nil {
+			return "", nil, err
+		}
+		from = " FROM " + q
+		args = append(args, a...)
+	}
+
 	where := ""
 	if u.Where != nil {
-		q, a := u.Where.Build()
+		q, a, err := clause(d, "Update", "Where", u.Where)
+		if err != nil {
+			return "", nil, err
+		}
 		where = " WHERE " + q
 		args = append(args, a...)
 	}
 
-	return "UPDATE " + table + set + where, args
+	orderBy := ""
+	if len(u.OrderBy) > 0 {
+		orderBy = " ORDER BY " + strings.Join(u.OrderBy, ",")
+	}
+	if u.Limit > 0 && is(d, "mysql") {
+		orderBy += " LIMIT ?"
+		args = append(args, u.Limit)
+	}
+
+	return "UPDATE " + top + table + set + from + where + orderBy + ret, args, nil
+}
+
+// isJoin reports whether b joins tables.
+func isJoin(b Builder) bool {
+	switch v := b.(type) {
+	case secJoin:
+		return true
+	case Joins:
+		return len(v.joins) > 0
+	}
+	return false
 }
 
 type Insert struct {
 	Table Builder
 	Value Builder
+	// Upsert follows Value, see OnDuplicateKeyUpdate and OnConflict.
+	Upsert    Builder
+	Returning []string
 }
 
 func (e Insert) Build() (string, []interface{}) {
+	return must(e.BuildE())
+}
+
+func (e Insert) BuildE() (string, []interface{}, error) {
+	return e.build(MySQL)
+}
+
+func (e Insert) build(d Dialect) (string, []interface{}, error) {
 	args := make([]interface{}, 0)
 
-	table, a := e.Table.Build()
+	table, a, err := clause(d, "Insert", "Table", e.Table)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
+	ret, err := returning(d, "Insert", "INSERTED", e.Returning)
+	if err != nil {
+		return "", nil, err
+	}
+
 	values := ""
 	if e.Value != nil {
-		values, a = e.Value.Build()
+		values, a, err = clause(d, "Insert", "Value", e.Value)
+		if err != nil {
+			return "", nil, err
+		}
 		args = append(args, a...)
 	}
 
-	return "INSERT INTO " + table + " " + values, args
+	if ret != "" && is(d, "sqlserver") {
+		// OUTPUT goes before the values
+		v, ok := e.Value.(secValues)
+		switch {
+		case ok && len(v.cols) > 0:
+			values = "(" + strings.Join(v.cols, ",") + ")" + ret + " " + v.values
+		case strings.HasPrefix(values, "("):
+			// the column list of a Raw value can't be told from the rest
+			return "", nil, &BuildError{Builder: "Insert", Clause: "Returning", Err: fmt.Errorf("%w %s, the column list of Value must be made by MakeValues", ErrUnsupported, d.Name())}
+		default:
+			values = ret[1:] + " " + values
+		}
+		ret = ""
+	}
+
+	upsert := ""
+	if e.Upsert != nil {
+		q, a, err := clause(d, "Insert", "Upsert", e.Upsert)
+		if err != nil {
+			return "", nil, err
+		}
+		upsert = " " + q
+		args = append(args, a...)
+	}
+
+	return "INSERT INTO " + table + " " + values + upsert + ret, args, nil
 }
 
+// Delete deletes from Table. On MySQL and SQL Server Table can be joined tables, whose rows are deleted
+// from the tables of Targets, DELETE t1 FROM t1 JOIN t2 ON ..., SQL Server accepts one target.
+// Using lists the other tables of the condition on PostgreSQL,
+// OrderBy and Limit bound the deleted rows of a single table on MySQL, Limit is TOP on SQL Server, 0 means no limit.
 type Delete struct {
-	Table Builder
-	Where Builder
+	Targets   []string
+	Table     Builder
+	Using     Builder
+	Where     Builder
+	OrderBy   []string
+	Limit     uint
+	Returning []string
+}
+
+func (del Delete) Build() (string, []interface{}) {
+	return must(del.BuildE())
+}
+
+func (del Delete) BuildE() (string, []interface{}, error) {
+	return del.build(MySQL)
 }
 
-func (d Delete) Build() (string, []interface{}) {
+func (del Delete) build(d Dialect) (string, []interface{}, error) {
+	switch {
+	case isJoin(del.Table) && is(d, "postgres"):
+		return "", nil, &BuildError{Builder: "Delete", Clause: "Table", Err: fmt.Errorf("%w %s, put the other tables in Using", ErrUnsupported, d.Name())}
+	case isJoin(del.Table) && !is(d, "mysql", "sqlserver"):
+		return "", nil, &BuildError{Builder: "Delete", Clause: "Table", Err: fmt.Errorf("%w %s, filter by a subquery in Where", ErrUnsupported, d.Name())}
+	case len(del.Targets) > 0 && !is(d, "mysql", "sqlserver"),
+		len(del.Targets) > 1 && is(d, "sqlserver"):
+		return "", nil, unsupported("Delete", "Targets", d)
+	case isJoin(del.Table) && len(del.Targets) == 0:
+		return "", nil, &BuildError{Builder: "Delete", Clause: "Targets", Err: fmt.Errorf("%w, joined tables need targets", ErrMultiTable)}
+	case del.Using != nil && !is(d, "postgres"):
+		return "", nil, unsupported("Delete", "Using", d)
+	case len(del.OrderBy) > 0 && !is(d, "mysql"):
+		return "", nil, unsupported("Delete", "OrderBy", d)
+	case del.Limit > 0 && !is(d, "mysql", "sqlserver"):
+		return "", nil, unsupported("Delete", "Limit", d)
+	case len(del.OrderBy) > 0 && len(del.Targets) > 0 && is(d, "mysql"):
+		return "", nil, &BuildError{Builder: "Delete", Clause: "OrderBy", Err: fmt.Errorf("%w, joined tables can't be ordered", ErrMultiTable)}
+	case del.Limit > 0 && len(del.Targets) > 0 && is(d, "mysql"):
+		return "", nil, &BuildError{Builder: "Delete", Clause: "Limit", Err: fmt.Errorf("%w, joined tables can't be limited", ErrMultiTable)}
+	}
+
 	args := make([]interface{}, 0)
 
-	table, a := d.Table.Build()
+	top := ""
+	if del.Limit > 0 && is(d, "sqlserver") {
+		top = "TOP (?) "
+		args = append(args, del.Limit)
+	}
+
+	table, a, err := clause(d, "Delete", "Table", del.Table)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, a...)
 
+	using := ""
+	if del.Using != nil {
+		q, a, err := clause(d, "Delete", "Using", del.Using)
+		if err != nil {
+			return "", nil, err
+		}
+		using = " USING " + q
+		args = append(args, a...)
+	}
+
 	where := ""
-	if d.Where != nil {
-		q, a := d.Where.Build()
+	if del.Where != nil {
+		q, a, err := clause(d, "Delete", "Where", del.Where)
+		if err != nil {
+			return "", nil, err
+		}
 		where = " WHERE " + q
 		args = append(args, a...)
 	}
 
-	return "DELETE FROM " + table + where, args
+	orderBy := ""
+	if len(del.OrderBy) > 0 {
+		orderBy = " ORDER BY " + strings.Join(del.OrderBy, ",")
+	}
+	if del.Limit > 0 && is(d, "mysql") {
+		orderBy += " LIMIT ?"
+		args = append(args, del.Limit)
+	}
+
+	ret, err := returning(d, "Delete", "DELETED", del.Returning)
+	if err != nil {
+		return "", nil, err
+	}
+
+	targets := ""
+	if len(del.Targets) > 0 {
+		targets = strings.Join(del.Targets, ",") + " "
+	}
+	if is(d, "sqlserver") {
+		// OUTPUT follows the target, which is the table itself if there is no Targets.
+		if targets != "" {
+			return "DELETE " + top + targets[:len(targets)-1] + ret + " FROM " + table + where, args, nil
+		}
+		return "DELETE " + top + "FROM " + table + ret + where, args, nil
+	}
+
+	return "DELETE " + targets + "FROM " + table + using + where + orderBy + ret, args, nil
 }
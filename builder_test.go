@@ -1,6 +1,7 @@
 package bsql
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -409,6 +410,11 @@ func TestMakeJoin(t *testing.T) {
 		ass.Equal(tc.out.cond, q)
 		ass.Equal(tc.out.vals, a)
 	}
+
+	full := MakeJoin(FullJoin, MakeAlias(Raw("t1"), "t1"), MakeAlias(Raw("t2"), "t2"), Raw("t1.id = t2.id"))
+	q, _ := Build(PostgreSQL, full)
+	ass.Equal("t1 AS t1 FULL OUTER JOIN t2 AS t2 ON t1.id = t2.id", q)
+	ass.True(errors.Is(Validate(MySQL, full), ErrUnsupported))
 }
 
 func TestEmbed(t *testing.T) {
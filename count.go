@@ -0,0 +1,57 @@
+package bsql
+
+type secCount struct {
+	b Builder
+}
+
+func (c secCount) Build() (string, []interface{}) {
+	return must(c.BuildE())
+}
+
+func (c secCount) BuildE() (string, []interface{}, error) {
+	return c.build(MySQL)
+}
+
+func (c secCount) build(d Dialect) (string, []interface{}, error) {
+	switch v := c.b.(type) {
+	case With:
+		// count the main query, the CTEs it refers to are kept
+		v.Query = secCount{b: v.Query}
+		return clause(d, "Count", "", v)
+	case secHint:
+		v.b = secCount{b: v.b}
+		return clause(d, "Count", "", v)
+	case secComment:
+		v.b = secCount{b: v.b}
+		return clause(d, "Count", "", v)
+	case Select:
+		v.OrderBy, v.Limit, v.Offset, v.Lock = nil, nil, 0, nil
+		if !v.Distinct && len(v.GroupBy) == 0 && v.Having == nil {
+			return clause(d, "Count", "", Select{Fields: []string{"COUNT(*)"}, Table: v.Table, Where: v.Where})
+		}
+		c.b = v
+	case SelectRaw:
+		v.OrderBy, v.Limit, v.Lock = nil, nil, nil
+		if !v.Distinct && v.GroupBy == nil && v.Having == nil {
+			return clause(d, "Count", "", SelectRaw{Fields: Raw("COUNT(*)"), Table: v.Table, Where: v.Where})
+		}
+		c.b = v
+	case SetOp:
+		v.OrderBy, v.Limit, v.Offset = nil, 0, 0
+		c.b = v
+	}
+
+	q, a, err := clause(d, "Count", "", c.b)
+	if err != nil {
+		return "", nil, err
+	}
+	return "SELECT COUNT(*) FROM (" + q + ") t", a, nil
+}
+
+// Count is the query counting the rows of b.
+// ORDER BY, LIMIT and the Lock of a Select or SelectRaw, and ORDER BY and LIMIT of a SetOp are dropped,
+// and it is made a derived table if it has DISTINCT, GROUP BY or HAVING, as any other query is.
+// The query of a With, Hint, Comment or Tags is counted inside of it.
+func Count(b Builder) Builder {
+	return secCount{b: b}
+}
@@ -0,0 +1,86 @@
+package bsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			Count(Select{Fields: []string{"id", "name"}, Table: Raw("users"), Where: EQ("a", 1), OrderBy: []string{"id"}, Limit: []uint{10}, Offset: 20}),
+			outStruct{
+				cond: "SELECT COUNT(*) FROM users WHERE a = $1",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			MySQL,
+			Count(Select{Fields: []string{"dept", "max(age)"}, Table: Raw("users"), Where: EQ("a", 1), GroupBy: []string{"dept"}, Having: Raw("max(age) > ?", 30), OrderBy: []string{"dept"}, Limit: []uint{0, 10}}),
+			outStruct{
+				cond: "SELECT COUNT(*) FROM (SELECT dept,max(age) FROM users WHERE a = ? GROUP BY dept HAVING max(age) > ?) t",
+				vals: []interface{}{1, 30},
+			},
+		},
+		{
+			SQLServer,
+			Count(Select{Distinct: true, Fields: []string{"dept"}, Table: Raw("users"), Limit: []uint{5}}),
+			outStruct{
+				cond: "SELECT COUNT(*) FROM (SELECT DISTINCT dept FROM users) t",
+				vals: []interface{}{},
+			},
+		},
+		{
+			MySQL,
+			Count(SelectRaw{Fields: Raw("id"), Table: Raw("users"), Where: EQ("a", 1), OrderBy: Raw("id"), Limit: Raw("?", 10)}),
+			outStruct{
+				cond: "SELECT COUNT(*) FROM users WHERE a = ?",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			MySQL,
+			Count(SetOp{Op: OpUnion, Queries: []Builder{Raw("SELECT id FROM t1"), Raw("SELECT id FROM t2 WHERE a = ?", 1)}, OrderBy: []string{"id"}, Limit: 10, Offset: 20}),
+			outStruct{
+				cond: "SELECT COUNT(*) FROM (SELECT id FROM t1 UNION SELECT id FROM t2 WHERE a = ?) t",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			PostgreSQL,
+			Count(Hint(Select{Table: Raw("t"), OrderBy: []string{"id"}, Limit: []uint{10}}, "x")),
+			outStruct{
+				cond: "/*+ x */ SELECT COUNT(*) FROM t",
+				vals: []interface{}{},
+			},
+		},
+		{
+			MySQL,
+			Count(Comment(With{CTEs: []CTE{{Name: "c", Query: Raw("SELECT id FROM t WHERE a = ?", 1)}}, Query: Select{Table: Raw("c"), Limit: []uint{10}, Offset: 20}}, "list")),
+			outStruct{
+				cond: "WITH c AS (SELECT id FROM t WHERE a = ?) SELECT COUNT(*) FROM c /*list*/",
+				vals: []interface{}{1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	ass.EqualError(Validate(MySQL, Count(Select{})), "bsql: Count: Select.Table: builder is nil")
+}
@@ -0,0 +1,92 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteJoin(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	join := From(Raw("orders o")).Join(Raw("users u"), Raw("u.id = o.user_id"))
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL,
+			Delete{Table: Raw("logs"), Where: LT("created_at", "2020-01-01"), OrderBy: []string{"id"}, Limit: 1000},
+			outStruct{
+				cond: "DELETE FROM logs WHERE created_at < ? ORDER BY id LIMIT ?",
+				vals: []interface{}{"2020-01-01", uint(1000)},
+			},
+		},
+		{
+			MySQL,
+			Delete{Targets: []string{"o", "u"}, Table: join, Where: EQ("u.banned", true)},
+			outStruct{
+				cond: "DELETE o,u FROM orders o JOIN users u ON u.id = o.user_id WHERE u.banned = ?",
+				vals: []interface{}{true},
+			},
+		},
+		{
+			PostgreSQL,
+			Delete{Table: Raw("orders o"), Using: Raw("users u"), Where: SecAND{Raw("u.id = o.user_id"), EQ("u.banned", true)}, Returning: []string{"o.id"}},
+			outStruct{
+				cond: "DELETE FROM orders o USING users u WHERE (u.id = o.user_id AND u.banned = $1) RETURNING o.id",
+				vals: []interface{}{true},
+			},
+		},
+		{
+			SQLServer,
+			Delete{Targets: []string{"o"}, Table: join, Where: EQ("u.banned", true), Limit: 100, Returning: []string{"id"}},
+			outStruct{
+				cond: "DELETE TOP (@p1) o OUTPUT DELETED.id FROM orders o JOIN users u ON u.id = o.user_id WHERE u.banned = @p2",
+				vals: []interface{}{uint(100), true},
+			},
+		},
+		{
+			SQLServer,
+			Delete{Table: Raw("logs"), Where: EQ("kind", 1), Limit: 100},
+			outStruct{
+				cond: "DELETE TOP (@p1) FROM logs WHERE kind = @p2",
+				vals: []interface{}{uint(100), 1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	var errs = []struct {
+		d  Dialect
+		in Delete
+	}{
+		{PostgreSQL, Delete{Targets: []string{"o"}, Table: join}},
+		{SQLServer, Delete{Targets: []string{"o", "u"}, Table: join}},
+		{MySQL, Delete{Table: Raw("a"), Using: Raw("b")}},
+		{SQLite, Delete{Table: Raw("a"), OrderBy: []string{"id"}}},
+		{Oracle, Delete{Table: Raw("a"), Limit: 1}},
+	}
+	for _, tc := range errs {
+		_, _, err := BuildE(tc.d, tc.in)
+		ass.True(errors.Is(err, ErrUnsupported), "%v", err)
+	}
+	ass.EqualError(Validate(PostgreSQL, Delete{Table: join}), "bsql: Delete.Table: not supported by dialect postgres, put the other tables in Using")
+	ass.EqualError(Validate(SQLite, Delete{Targets: []string{"o"}, Table: join}), "bsql: Delete.Table: not supported by dialect sqlite3, filter by a subquery in Where")
+	ass.True(errors.Is(Validate(MySQL, Delete{Table: join}), ErrMultiTable))
+	ass.EqualError(Validate(MySQL, Delete{Table: join}), "bsql: Delete.Targets: invalid multiple-table statement, joined tables need targets")
+	ass.EqualError(Validate(MySQL, Delete{Targets: []string{"o"}, Table: join, OrderBy: []string{"id"}}), "bsql: Delete.OrderBy: invalid multiple-table statement, joined tables can't be ordered")
+	ass.EqualError(Validate(MySQL, Delete{Targets: []string{"o"}, Table: join, Limit: 1}), "bsql: Delete.Limit: invalid multiple-table statement, joined tables can't be limited")
+}
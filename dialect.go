@@ -0,0 +1,222 @@
+package bsql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect describes how a query is rendered for a particular database.
+//
+// Builders always emit '?' placeholders, Build rewrites them into the
+// dialect's own form once the whole query is assembled, so nested builders
+// are numbered in the order their arguments appear.
+type Dialect interface {
+	Name() string
+	// Placeholder returns the placeholder of the n-th argument, starting at 1.
+	Placeholder(n int) string
+	// QuoteIdent quotes a single identifier, quote characters inside it are escaped.
+	QuoteIdent(name string) string
+}
+
+var (
+	MySQL      Dialect = mysql{}
+	PostgreSQL Dialect = postgres{}
+	SQLite     Dialect = sqlite{}
+	SQLServer  Dialect = sqlserver{}
+	Oracle     Dialect = oracle{}
+)
+
+type mysql struct{}
+
+func (mysql) Name() string { return "mysql" }
+
+func (mysql) Placeholder(int) string { return "?" }
+
+func (mysql) QuoteIdent(name string) string { return "`" + strings.Replace(name, "`", "``", -1) + "`" }
+
+type postgres struct{}
+
+func (postgres) Name() string { return "postgres" }
+
+func (postgres) Placeholder(n int) string { return "$" + strconv.Itoa(n) }
+
+func (postgres) QuoteIdent(name string) string { return quoteIdent(name) }
+
+type sqlite struct{}
+
+func (sqlite) Name() string { return "sqlite3" }
+
+func (sqlite) Placeholder(int) string { return "?" }
+
+func (sqlite) QuoteIdent(name string) string { return quoteIdent(name) }
+
+type sqlserver struct{}
+
+func (sqlserver) Name() string { return "sqlserver" }
+
+func (sqlserver) Placeholder(n int) string { return "@p" + strconv.Itoa(n) }
+
+func (sqlserver) QuoteIdent(name string) string {
+	return "[" + strings.Replace(name, "]", "]]", -1) + "]"
+}
+
+type oracle struct{}
+
+func (oracle) Name() string { return "oracle" }
+
+func (oracle) Placeholder(n int) string { return ":" + strconv.Itoa(n) }
+
+func (oracle) QuoteIdent(name string) string { return quoteIdent(name) }
+
+// is reports whether d is one of the named dialects.
+func is(d Dialect, names ...string) bool {
+	for _, v := range names {
+		if d.Name() == v {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// Build builds b and rewrites its placeholders for d.
+func Build(d Dialect, b Builder) (string, []interface{}) {
+	return must(BuildE(d, b))
+}
+
+// Rebind rewrites the '?' placeholders of query for d.
+// Question marks inside quoted strings, quoted identifiers and comments are left as is.
+func Rebind(d Dialect, query string) string {
+	if d == nil || d.Placeholder(1) == "?" {
+		return query
+	}
+
+	return replacePlaceholders(query, is(d, "sqlserver"), d.Placeholder)
+}
+
+// replacePlaceholders replaces the n-th '?' of query, starting at 1, with fn(n),
+// skipping quoted strings, quoted identifiers, [bracketed] ones if brackets is set, and comments.
+func replacePlaceholders(query string, brackets bool, fn func(n int) string) string {
+	n := 0
+	return rewriteSQL(query, brackets, func(i int) (string, int) {
+		if query[i] != '?' {
+			return "", 0
+		}
+		n++
+		return fn(n), 1
+	})
+}
+
+// rewriteSQL calls fn at each byte of query outside of quoted strings, quoted identifiers and comments,
+// fn returns the text to replace the next size bytes with, or a size of 0 to keep the byte.
+func rewriteSQL(query string, brackets bool, fn func(i int) (repl string, size int)) string {
+	b := strings.Builder{}
+	b.Grow(len(query) + 8)
+	end := len(query)
+	for i := 0; i < end; i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[' && brackets:
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			j := i + 1
+			for j < end && query[j] != closing {
+				j++
+			}
+			if j >= end {
+				j = end - 1
+			}
+			b.WriteString(query[i : j+1])
+			i = j
+			continue
+		case c == '-' && i+1 < end && query[i+1] == '-':
+			j := strings.IndexByte(query[i:], '\n')
+			if j < 0 {
+				j = end - i - 1
+			}
+			b.WriteString(query[i : i+j+1])
+			i += j
+			continue
+		case c == '/' && i+1 < end && query[i+1] == '*':
+			j := strings.Index(query[i+2:], "*/")
+			if j < 0 {
+				j = end - i - 4
+			}
+			b.WriteString(query[i : i+j+4])
+			i += j + 3
+			continue
+		}
+		if repl, size := fn(i); size > 0 {
+			b.WriteString(repl)
+			i += size - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// returning renders the RETURNING clause of the named builder,
+// or its OUTPUT clause on SQL Server, where each column is taken from the prefix table, INSERTED or DELETED.
+func returning(d Dialect, name, prefix string, cols []string) (string, error) {
+	if len(cols) == 0 {
+		return "", nil
+	}
+	switch {
+	case is(d, "postgres", "sqlite3"):
+		return " RETURNING " + strings.Join(cols, ","), nil
+	case is(d, "sqlserver"):
+		ss := make([]string, len(cols))
+		for i, v := range cols {
+			ss[i] = prefix + "." + v
+		}
+		return " OUTPUT " + strings.Join(ss, ","), nil
+	}
+	return "", unsupported(name, "Returning", d)
+}
+
+// MaxPageSize is the largest page size accepted by Select.Paginate, 0 means no bound.
+var MaxPageSize = 1000
+
+// paginate renders orderBy followed by the clauses which skip offset rows,
+// and return at most limit rows if hasLimit.
+func paginate(d Dialect, orderBy string, limit, offset uint, hasLimit bool) (string, []interface{}) {
+	if !hasLimit && offset == 0 {
+		return orderBy, nil
+	}
+
+	var args []interface{}
+	switch {
+	case is(d, "sqlserver", "oracle"):
+		if orderBy == "" && is(d, "sqlserver") {
+			orderBy = " ORDER BY (SELECT NULL)"
+		}
+		if offset > 0 || is(d, "sqlserver") {
+			orderBy += " OFFSET ? ROWS"
+			args = append(args, offset)
+		}
+		if hasLimit {
+			orderBy += " FETCH NEXT ? ROWS ONLY"
+			args = append(args, limit)
+		}
+		return orderBy, args
+	case hasLimit:
+		orderBy += " LIMIT ?"
+		args = append(args, limit)
+	case is(d, "mysql"):
+		orderBy += " LIMIT 18446744073709551615"
+	case is(d, "sqlite3"):
+		orderBy += " LIMIT -1"
+	}
+	if offset > 0 {
+		orderBy += " OFFSET ?"
+		args = append(args, offset)
+	}
+	return orderBy, args
+}
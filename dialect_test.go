@@ -0,0 +1,101 @@
+package bsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	sel := Select{
+		Table: MakeJoin(InnerJoin, Raw("t1"), Raw("t2"), Raw("t1.id = t2.id AND t2.kind = ?", 1)),
+		Where: SecAND{
+			Embed("ifnull($,0) > $", Func("max", Raw("a"), Raw("?", 2)), Raw("?", 3)),
+			MakeIn("b", []interface{}{4, 5}),
+			Raw("c = '?'"),
+		},
+		Limit: []uint{10},
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL, sel,
+			outStruct{
+				cond: "SELECT * FROM t1 JOIN t2 ON t1.id = t2.id AND t2.kind = ? WHERE (ifnull(max(a,?),0) > ? AND b IN (?,?) AND c = '?') LIMIT ?",
+				vals: []interface{}{1, 2, 3, 4, 5, uint(10)},
+			},
+		},
+		{
+			PostgreSQL, sel,
+			outStruct{
+				cond: "SELECT * FROM t1 JOIN t2 ON t1.id = t2.id AND t2.kind = $1 WHERE (ifnull(max(a,$2),0) > $3 AND b IN ($4,$5) AND c = '?') LIMIT $6",
+				vals: []interface{}{1, 2, 3, 4, 5, uint(10)},
+			},
+		},
+		{
+			SQLServer, Update{Table: Raw("tb"), Set: Raw("a = ?", 1), Where: Raw("id = ?", 2)},
+			outStruct{
+				cond: "UPDATE tb SET a = @p1 WHERE id = @p2",
+				vals: []interface{}{1, 2},
+			},
+		},
+		{
+			Oracle, Delete{Table: Raw("tb"), Where: Raw("id = ? /* ? */", 1)},
+			outStruct{
+				cond: "DELETE FROM tb WHERE id = :1 /* ? */",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLite, Insert{Table: Raw("tb"), Value: Raw("VALUES (?,?)", 1, 2)},
+			outStruct{
+				cond: "INSERT INTO tb VALUES (?,?)",
+				vals: []interface{}{1, 2},
+			},
+		},
+		{
+			PostgreSQL, UnionAll{
+				{Table: Raw("t1"), Where: Raw("a = ?", 1)},
+				{Table: Raw("t2"), Where: Raw("b = ?", 2)},
+			},
+			outStruct{
+				cond: "SELECT * FROM t1 WHERE a = $1 UNION ALL SELECT * FROM t2 WHERE b = $2",
+				vals: []interface{}{1, 2},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+}
+
+func TestRebind(t *testing.T) {
+	var data = []struct {
+		in  string
+		out string
+	}{
+		{"a = ? AND b = ?", "a = $1 AND b = $2"},
+		{`a = '?' AND "b?" = ? AND ` + "`c?`" + ` = ?`, `a = '?' AND "b?" = $1 AND ` + "`c?`" + ` = $2`},
+		{"a = ? -- ?\nAND b = ?", "a = $1 -- ?\nAND b = $2"},
+		{"a = ? /* ? */ AND b = ?", "a = $1 /* ? */ AND b = $2"},
+		{"a = 'it''s ?' AND b = ?", "a = 'it''s ?' AND b = $1"},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		ass.Equal(tc.out, Rebind(PostgreSQL, tc.in))
+	}
+}
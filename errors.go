@@ -0,0 +1,141 @@
+package bsql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNilBuilder          = errors.New("builder is nil")
+	ErrPlaceholderMismatch = errors.New("the number of places does not match")
+	ErrJoinType            = errors.New("unknown join type")
+	ErrJoinCondition       = errors.New("invalid join condition")
+	ErrSetOpType           = errors.New("unknown set operation")
+	ErrPage                = errors.New("invalid page")
+	ErrLock                = errors.New("invalid lock")
+	ErrConflictTarget      = errors.New("invalid conflict target")
+	ErrMultiTable          = errors.New("invalid multiple-table statement")
+	ErrInvalidIdent        = errors.New("invalid identifier")
+	ErrOperator            = errors.New("invalid comparison operator")
+	ErrEmptySlice          = errors.New("empty slice argument")
+	ErrMissingName         = errors.New("missing named parameter")
+	ErrUnusedName          = errors.New("unused named parameter")
+	ErrCursor              = errors.New("invalid cursor")
+	ErrHint                = errors.New("no statement to hint")
+	ErrHintText            = errors.New("hint contains * or /")
+	ErrUnsupported         = errors.New("not supported by dialect")
+)
+
+// ErrBuilder is implemented by builders which report malformed input as an error instead of panicking.
+// Build of such a builder panics with the error returned by BuildE.
+type ErrBuilder interface {
+	BuildE() (string, []interface{}, error)
+}
+
+// BuildError reports which builder and which clause of it failed to build.
+// Err is the cause, it is another *BuildError if the failure happened in a nested builder.
+type BuildError struct {
+	Builder string
+	Clause  string
+	Err     error
+}
+
+func (e *BuildError) Error() string {
+	b := strings.Builder{}
+	b.WriteString("bsql: ")
+	var err error = e
+	for {
+		be, ok := err.(*BuildError)
+		if !ok {
+			break
+		}
+		b.WriteString(be.Builder)
+		if be.Clause != "" {
+			if be.Clause[0] != '[' {
+				b.WriteString(".")
+			}
+			b.WriteString(be.Clause)
+		}
+		b.WriteString(": ")
+		err = be.Err
+	}
+	if err != nil {
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// BuildE is the error returning form of Build.
+func BuildE(d Dialect, b Builder) (string, []interface{}, error) {
+	if d == nil {
+		d = MySQL
+	}
+	q, a, err := build(d, b)
+	if err != nil {
+		return "", nil, err
+	}
+	return Rebind(d, q), a, nil
+}
+
+// Validate builds b for d and reports the first error found in its tree.
+func Validate(d Dialect, b Builder) error {
+	if d == nil {
+		d = MySQL
+	}
+	_, _, err := build(d, b)
+	return err
+}
+
+// dialectBuilder is implemented by the builders of this package,
+// it passes the dialect down the tree so the clauses can be rendered for it.
+type dialectBuilder interface {
+	build(d Dialect) (string, []interface{}, error)
+}
+
+func build(d Dialect, b Builder) (q string, a []interface{}, err error) {
+	if b == nil {
+		return "", nil, ErrNilBuilder
+	}
+	if db, ok := b.(dialectBuilder); ok {
+		return db.build(d)
+	}
+	if e, ok := b.(ErrBuilder); ok {
+		return e.BuildE()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	q, a = b.Build()
+	return q, a, nil
+}
+
+func unsupported(name, clause string, d Dialect) error {
+	return &BuildError{Builder: name, Clause: clause, Err: fmt.Errorf("%w %s", ErrUnsupported, d.Name())}
+}
+
+// clause builds b as the named clause of the named builder.
+func clause(d Dialect, name, clause string, b Builder) (string, []interface{}, error) {
+	q, a, err := build(d, b)
+	if err != nil {
+		return "", nil, &BuildError{Builder: name, Clause: clause, Err: err}
+	}
+	return q, a, nil
+}
+
+func must(q string, a []interface{}, err error) (string, []interface{}) {
+	if err != nil {
+		panic(err)
+	}
+	return q, a
+}
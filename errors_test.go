@@ -0,0 +1,110 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type panicBuilder struct{}
+
+func (panicBuilder) Build() (string, []interface{}) {
+	panic(errors.New("boom"))
+}
+
+func TestBuildE(t *testing.T) {
+	var data = []struct {
+		in    Builder
+		cause error
+		msg   string
+	}{
+		{
+			Select{Where: Raw("a = ?", 1)},
+			ErrNilBuilder,
+			"bsql: Select.Table: builder is nil",
+		},
+		{
+			SelectRaw{Table: Raw("tb")},
+			ErrNilBuilder,
+			"bsql: SelectRaw.Fields: builder is nil",
+		},
+		{
+			Update{Set: Raw("a = 1")},
+			ErrNilBuilder,
+			"bsql: Update.Table: builder is nil",
+		},
+		{
+			Insert{Value: Raw("VALUES (1)")},
+			ErrNilBuilder,
+			"bsql: Insert.Table: builder is nil",
+		},
+		{
+			Delete{},
+			ErrNilBuilder,
+			"bsql: Delete.Table: builder is nil",
+		},
+		{
+			Select{
+				Table: Raw("tb"),
+				Where: SecAND{
+					Raw("a = ?", 1),
+					SecOR{Embed("max($,$)", Raw("b"))},
+				},
+			},
+			ErrPlaceholderMismatch,
+			"bsql: Select.Where: SecAND[1]: SecOR[0]: Embed: the number of places does not match",
+		},
+		{
+			Select{Table: MakeJoin(8, Raw("t1"), Raw("t2"), nil)},
+			ErrJoinType,
+			"bsql: Select.Table: MakeJoin: unknown join type 8",
+		},
+		{
+			UnionAll{{Table: Raw("t1")}, {Table: MakeAlias(Bracket(Func("f", nil)), "t")}},
+			ErrNilBuilder,
+			"bsql: UnionAll[1]: Select.Table: MakeAlias.t: Bracket: Func.f[0]: builder is nil",
+		},
+		{
+			Update{Table: Raw("tb"), Set: SecComma{Raw("a = 1"), panicBuilder{}}},
+			nil,
+			"bsql: Update.Set: SecComma[1]: boom",
+		},
+		{
+			SecCase{When: [][2]Builder{{Raw("1"), nil}}},
+			ErrNilBuilder,
+			"bsql: SecCase.Then[0]: builder is nil",
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		_, _, err := BuildE(PostgreSQL, tc.in)
+		if ass.Error(err) {
+			ass.Equal(tc.msg, err.Error())
+			if tc.cause != nil {
+				ass.True(errors.Is(err, tc.cause))
+			}
+			var be *BuildError
+			ass.True(errors.As(err, &be))
+		}
+		ass.Equal(err, Validate(PostgreSQL, tc.in))
+		ass.Panics(func() { tc.in.Build() })
+	}
+
+	q, a, err := BuildE(PostgreSQL, Select{Table: Raw("tb"), Where: Raw("a = ?", 1)})
+	ass.NoError(err)
+	ass.Equal("SELECT * FROM tb WHERE a = $1", q)
+	ass.Equal([]interface{}{1}, a)
+
+	values, err := MakeValues([]string{"id"}, [][]interface{}{{1}})
+	ass.NoError(err)
+	upsert := Insert{Table: Raw("tb"), Value: values, Upsert: OnConflict{Target: []string{"id"}}}
+	using := Delete{Table: Raw("a"), Using: Raw("b"), Where: Raw("a.id = b.id")}
+	lock := Select{Table: Raw("tb"), Lock: &Lock{Mode: ForNoKeyUpdate}}
+	for _, b := range []Builder{upsert, using, lock} {
+		ass.NoError(Validate(PostgreSQL, b))
+		ass.True(errors.Is(Validate(MySQL, b), ErrUnsupported))
+	}
+	ass.NoError(Validate(nil, Select{Table: Raw("tb")}))
+}
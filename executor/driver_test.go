@@ -0,0 +1,121 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// fakeDriver is a database/sql driver that records the queries it runs and answers them with canned rows.
+type fakeDriver struct{}
+
+var (
+	fakeDBs  sync.Map
+	fakeSeq  int64
+	fakeOnce sync.Once
+)
+
+type fakeRows struct {
+	cols []string
+	vals [][]driver.Value
+	err  error
+}
+
+type fakeDB struct {
+	mu      sync.Mutex
+	queries []string
+	args    [][]interface{}
+	results []fakeRows
+}
+
+// newFakeDB returns a database answering its queries with results in order.
+func newFakeDB(results ...fakeRows) (*sql.DB, *fakeDB) {
+	fakeOnce.Do(func() {
+		sql.Register("bsqlfake", fakeDriver{})
+	})
+	name := strconv.FormatInt(atomic.AddInt64(&fakeSeq, 1), 10)
+	f := &fakeDB{results: results}
+	fakeDBs.Store(name, f)
+	db, err := sql.Open("bsqlfake", name)
+	if err != nil {
+		panic(err)
+	}
+	return db, f
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	f, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, errors.New("unknown fake db " + name)
+	}
+	return &fakeConn{db: f.(*fakeDB)}, nil
+}
+
+func (f *fakeDB) record(query string, args []driver.NamedValue) fakeRows {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a := make([]interface{}, len(args))
+	for i, v := range args {
+		a[i] = v.Value
+	}
+	f.queries = append(f.queries, query)
+	f.args = append(f.args, a)
+	if len(f.results) == 0 {
+		return fakeRows{}
+	}
+	r := f.results[0]
+	f.results = f.results[1:]
+	return r
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	r := c.db.record(query, args)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	r := c.db.record(query, args)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error { return nil }
+
+func (fakeTx) Rollback() error { return nil }
+
+func (r *fakeRows) Columns() []string { return r.cols }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.vals) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.vals[0])
+	r.vals = r.vals[1:]
+	return nil
+}
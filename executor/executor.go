@@ -0,0 +1,164 @@
+// Package executor runs the queries built by bsql on a database/sql connection.
+package executor
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/forsaken628/bsql"
+)
+
+// Queryer is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Error is returned when the database fails to run a built query, it carries the query.
+type Error struct {
+	Query string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return "bsql: " + e.Err.Error() + ", query: " + e.Query
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type Executor struct {
+	Queryer Queryer
+	Dialect bsql.Dialect
+	Mapper
+}
+
+// New returns an Executor building the queries for d and running them on q.
+func New(q Queryer, d bsql.Dialect) *Executor {
+	return &Executor{
+		Queryer: q,
+		Dialect: d,
+	}
+}
+
+func (e *Executor) Query(ctx context.Context, b bsql.Builder) (*sql.Rows, error) {
+	rows, _, err := e.query(ctx, b)
+	return rows, err
+}
+
+// QueryRow is like Query, but the error is deferred to the Scan of the returned Row.
+func (e *Executor) QueryRow(ctx context.Context, b bsql.Builder) *Row {
+	rows, q, err := e.query(ctx, b)
+	return &Row{rows: rows, query: q, err: err, mapper: e.Mapper}
+}
+
+func (e *Executor) query(ctx context.Context, b bsql.Builder) (*sql.Rows, string, error) {
+	q, a, err := bsql.BuildE(e.Dialect, b)
+	if err != nil {
+		return nil, "", err
+	}
+	rows, err := e.Queryer.QueryContext(ctx, q, a...)
+	if err != nil {
+		return nil, q, &Error{Query: q, Err: err}
+	}
+	return rows, q, nil
+}
+
+func (e *Executor) Exec(ctx context.Context, b bsql.Builder) (sql.Result, error) {
+	q, a, err := bsql.BuildE(e.Dialect, b)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.Queryer.ExecContext(ctx, q, a...)
+	if err != nil {
+		return nil, &Error{Query: q, Err: err}
+	}
+	return r, nil
+}
+
+// Get scans the first row into dest, which is a pointer to a struct or to a single column value, see Mapper.
+// It returns an error wrapping sql.ErrNoRows if there is no row.
+func (e *Executor) Get(ctx context.Context, dest interface{}, b bsql.Builder) error {
+	return e.QueryRow(ctx, b).scanInto(dest)
+}
+
+// Select scans all the rows into dest, which is a pointer to a slice.
+// The rows of an Insert, Update or Delete with Returning are scanned the same way.
+func (e *Executor) Select(ctx context.Context, dest interface{}, b bsql.Builder) error {
+	rows, q, err := e.query(ctx, b)
+	if err != nil {
+		return err
+	}
+	if err := e.ScanAll(rows, dest); err != nil {
+		return &Error{Query: q, Err: err}
+	}
+	return nil
+}
+
+// SelectCount is like Select, and also returns the number of all the rows of b regardless of its LIMIT,
+// which is counted by bsql.Count.
+func (e *Executor) SelectCount(ctx context.Context, dest interface{}, b bsql.Builder) (int64, error) {
+	if err := e.Select(ctx, dest, b); err != nil {
+		return 0, err
+	}
+	var total int64
+	if err := e.QueryRow(ctx, bsql.Count(b)).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+type Row struct {
+	rows   *sql.Rows
+	query  string
+	err    error
+	mapper Mapper
+}
+
+func (r *Row) Err() error {
+	return r.err
+}
+
+// Scan copies the columns of the first row into dest, as sql.Row.Scan does.
+func (r *Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+	if !r.rows.Next() {
+		return r.wrap(r.noRows())
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return r.wrap(err)
+	}
+	return r.wrap(r.rows.Close())
+}
+
+func (r *Row) scanInto(dest interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+	if !r.rows.Next() {
+		return r.wrap(r.noRows())
+	}
+	if err := r.mapper.ScanRow(r.rows, dest); err != nil {
+		return r.wrap(err)
+	}
+	return r.wrap(r.rows.Close())
+}
+
+func (r *Row) noRows() error {
+	if err := r.rows.Err(); err != nil {
+		return err
+	}
+	return sql.ErrNoRows
+}
+
+func (r *Row) wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Query: r.query, Err: err}
+}
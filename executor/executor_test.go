@@ -0,0 +1,150 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/forsaken628/bsql"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID     int64  `db:"id"`
+	Name   string `db:"name"`
+	Age    int
+	ignore string
+	Skip   string `db:"-"`
+}
+
+func TestExecutor(t *testing.T) {
+	ass := assert.New(t)
+	ctx := context.Background()
+
+	db, f := newFakeDB(
+		fakeRows{cols: []string{"id", "name", "age"}, vals: [][]driver.Value{{int64(1), "a", int64(20)}, {int64(2), "b", int64(30)}}},
+		fakeRows{cols: []string{"id", "name"}, vals: [][]driver.Value{{int64(3), "c"}}},
+		fakeRows{cols: []string{"count"}, vals: [][]driver.Value{{int64(7)}}},
+		fakeRows{cols: []string{"name"}},
+		fakeRows{err: errors.New("bad query")},
+		fakeRows{cols: []string{"id"}, vals: [][]driver.Value{{int64(4)}, {int64(5)}}},
+	)
+	defer db.Close()
+	e := New(db, bsql.PostgreSQL)
+
+	var users []user
+	ass.NoError(e.Select(ctx, &users, bsql.Select{Table: bsql.Raw("users"), Where: bsql.GT("age", 10)}))
+	ass.Equal([]user{{ID: 1, Name: "a", Age: 20}, {ID: 2, Name: "b", Age: 30}}, users)
+	ass.Equal("SELECT * FROM users WHERE age > $1", f.queries[0])
+	ass.Equal([]interface{}{int64(10)}, f.args[0])
+
+	var u user
+	ass.NoError(e.Get(ctx, &u, bsql.Select{Table: bsql.Raw("users"), Where: bsql.EQ("id", 3)}))
+	ass.Equal(user{ID: 3, Name: "c"}, u)
+
+	var count int
+	ass.NoError(e.Get(ctx, &count, bsql.Select{Table: bsql.Raw("users"), Fields: []string{"count(*) AS count"}}))
+	ass.Equal(7, count)
+
+	var name string
+	err := e.QueryRow(ctx, bsql.Select{Table: bsql.Raw("users"), Fields: []string{"name"}}).Scan(&name)
+	ass.True(errors.Is(err, sql.ErrNoRows))
+
+	_, err = e.Exec(ctx, bsql.Delete{Table: bsql.Raw("users"), Where: bsql.EQ("id", 1)})
+	var ee *Error
+	if ass.True(errors.As(err, &ee)) {
+		ass.Equal("DELETE FROM users WHERE id = $1", ee.Query)
+		ass.Equal("bsql: bad query, query: DELETE FROM users WHERE id = $1", err.Error())
+	}
+
+	var ids []*int64
+	ass.NoError(e.Select(ctx, &ids, bsql.Select{Table: bsql.Raw("users"), Fields: []string{"id"}}))
+	if ass.Len(ids, 2) {
+		ass.Equal(int64(4), *ids[0])
+		ass.Equal(int64(5), *ids[1])
+	}
+
+	_, err = e.Exec(ctx, bsql.Delete{Where: bsql.EQ("id", 1)})
+	ass.True(errors.Is(err, bsql.ErrNilBuilder))
+	ass.Len(f.queries, 6)
+}
+
+func TestExecutor_Queryer(t *testing.T) {
+	ass := assert.New(t)
+	ctx := context.Background()
+
+	db, f := newFakeDB()
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, nil)
+	ass.NoError(err)
+	r, err := New(tx, bsql.MySQL).Exec(ctx, bsql.Update{Table: bsql.Raw("tb"), Set: bsql.Raw("a = ?", 1)})
+	ass.NoError(err)
+	n, _ := r.RowsAffected()
+	ass.Equal(int64(1), n)
+	ass.NoError(tx.Commit())
+
+	conn, err := db.Conn(ctx)
+	ass.NoError(err)
+	_, err = New(conn, bsql.MySQL).Exec(ctx, bsql.Delete{Table: bsql.Raw("tb")})
+	ass.NoError(err)
+	ass.NoError(conn.Close())
+
+	ass.Equal([]string{"UPDATE tb SET a = ?", "DELETE FROM tb"}, f.queries)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = New(db, bsql.MySQL).Exec(canceled, bsql.Delete{Table: bsql.Raw("tb")})
+	ass.True(errors.Is(err, context.Canceled))
+}
+
+func TestExecutor_Returning(t *testing.T) {
+	ass := assert.New(t)
+	ctx := context.Background()
+
+	db, f := newFakeDB(
+		fakeRows{cols: []string{"id", "name"}, vals: [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}},
+	)
+	defer db.Close()
+
+	v, err := bsql.MakeValues([]string{"name"}, [][]interface{}{{"a"}, {"b"}})
+	ass.NoError(err)
+
+	var users []user
+	ass.NoError(New(db, bsql.PostgreSQL).Select(ctx, &users, bsql.Insert{
+		Table:     bsql.Raw("users"),
+		Value:     v,
+		Returning: []string{"id", "name"},
+	}))
+	ass.Equal([]user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, users)
+	ass.Equal([]string{"INSERT INTO users (name) VALUES ($1),($2) RETURNING id,name"}, f.queries)
+}
+
+func TestExecutor_SelectCount(t *testing.T) {
+	ass := assert.New(t)
+	ctx := context.Background()
+
+	db, f := newFakeDB(
+		fakeRows{cols: []string{"id", "name"}, vals: [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}},
+		fakeRows{cols: []string{"count"}, vals: [][]driver.Value{{int64(42)}}},
+	)
+	defer db.Close()
+
+	var users []user
+	total, err := New(db, bsql.PostgreSQL).SelectCount(ctx, &users, bsql.Select{
+		Table:   bsql.Raw("users"),
+		Where:   bsql.GT("age", 10),
+		OrderBy: []string{"id"},
+		Limit:   []uint{2},
+	})
+	ass.NoError(err)
+	ass.Equal(int64(42), total)
+	ass.Equal([]user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, users)
+	ass.Equal([]string{
+		"SELECT * FROM users WHERE age > $1 ORDER BY id LIMIT $2",
+		"SELECT COUNT(*) FROM users WHERE age > $1",
+	}, f.queries)
+	ass.Equal([]interface{}{int64(10)}, f.args[1])
+}
@@ -0,0 +1,148 @@
+package executor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/forsaken628/bsql/internal/dbtag"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// Mapper scans rows into Go values.
+//
+// A struct is filled by column name, a field is named by its `db` tag,
+// or by its lower cased name without a tag, `db:"-"` skips it.
+// The fields of embedded structs are promoted, nil embedded pointers are allocated.
+// Types implementing sql.Scanner and time.Time are scanned as single values.
+type Mapper struct {
+	// Unsafe skips the columns which have no matching field, instead of failing.
+	Unsafe bool
+}
+
+// fields caches the column names of each struct type with the indexes of their fields.
+var fields sync.Map
+
+func fieldMap(t reflect.Type) map[string][]int {
+	if m, ok := fields.Load(t); ok {
+		return m.(map[string][]int)
+	}
+
+	m := make(map[string][]int)
+	for _, f := range dbtag.Fields(t, isScalar) {
+		m[f.Name] = f.Index
+	}
+
+	fields.Store(t, m)
+	return m
+}
+
+// isScalar reports whether a value of t is scanned from a single column instead of mapped by field.
+func isScalar(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == timeType {
+		return true
+	}
+	return reflect.PtrTo(t).Implements(scannerType)
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, allocating the nil embedded pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// ScanRow scans the current row of rows into dest, which is a pointer to a struct or to a single column value.
+func (m Mapper) ScanRow(rows *sql.Rows, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("dest must be a non nil pointer")
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	targets, err := m.targets(v.Elem(), cols)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(targets...)
+}
+
+// ScanAll scans the remaining rows into dest, which is a pointer to a slice, and closes rows.
+func (m Mapper) ScanAll(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New("dest must be a non nil pointer to a slice")
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		e := reflect.New(elem)
+		targets, err := m.targets(e.Elem(), cols)
+		if err != nil {
+			return err
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return err
+		}
+		if isPtr {
+			slice.Set(reflect.Append(slice, e))
+		} else {
+			slice.Set(reflect.Append(slice, e.Elem()))
+		}
+	}
+
+	return rows.Err()
+}
+
+// targets returns the pointers rows.Scan copies cols into.
+func (m Mapper) targets(v reflect.Value, cols []string) ([]interface{}, error) {
+	if isScalar(v.Type()) {
+		if len(cols) != 1 {
+			return nil, fmt.Errorf("scannable dest type %s with %d columns", v.Type(), len(cols))
+		}
+		return []interface{}{v.Addr().Interface()}, nil
+	}
+
+	fm := fieldMap(v.Type())
+	targets := make([]interface{}, len(cols))
+	for i, col := range cols {
+		index, ok := fm[col]
+		if !ok {
+			if m.Unsafe {
+				targets[i] = new(interface{})
+				continue
+			}
+			return nil, fmt.Errorf("missing destination name %s in %s", col, v.Type())
+		}
+		targets[i] = fieldByIndex(v, index).Addr().Interface()
+	}
+	return targets, nil
+}
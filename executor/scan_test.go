@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/forsaken628/bsql"
+	"github.com/stretchr/testify/assert"
+)
+
+type base struct {
+	ID      int64     `db:"id"`
+	Created time.Time `db:"created_at"`
+}
+
+type Audit struct {
+	By   string `db:"updated_by"`
+	Note string `db:"note"`
+}
+
+type order struct {
+	base
+	*Audit
+	Note   string         `db:"note"`
+	Amount *int64         `db:"amount"`
+	Coupon sql.NullString `db:"coupon"`
+	Tags   []byte         `db:"tags"`
+}
+
+func TestMapper(t *testing.T) {
+	ass := assert.New(t)
+	ctx := context.Background()
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cols := []string{"id", "created_at", "updated_by", "note", "amount", "coupon", "tags"}
+	db, _ := newFakeDB(
+		fakeRows{cols: cols, vals: [][]driver.Value{
+			{int64(1), now, "bob", "first", int64(100), "off", []byte("x")},
+			{int64(2), now, "", "second", nil, nil, nil},
+		}},
+		fakeRows{cols: append(cols, "unknown"), vals: [][]driver.Value{
+			{int64(1), now, "bob", "first", int64(100), "off", []byte("x"), "?"},
+		}},
+		fakeRows{cols: append(cols, "unknown"), vals: [][]driver.Value{
+			{int64(3), now, "amy", "third", int64(5), nil, nil, "?"},
+		}},
+		fakeRows{cols: []string{"created_at"}, vals: [][]driver.Value{{now}}},
+		fakeRows{cols: []string{"a", "b"}, vals: [][]driver.Value{{int64(1), int64(2)}}},
+	)
+	defer db.Close()
+	e := New(db, bsql.MySQL)
+	sel := bsql.Select{Table: bsql.Raw("orders")}
+
+	var orders []*order
+	ass.NoError(e.Select(ctx, &orders, sel))
+	if ass.Len(orders, 2) {
+		amount := int64(100)
+		ass.Equal(&order{
+			base:   base{ID: 1, Created: now},
+			Audit:  &Audit{By: "bob"},
+			Note:   "first",
+			Amount: &amount,
+			Coupon: sql.NullString{String: "off", Valid: true},
+			Tags:   []byte("x"),
+		}, orders[0])
+		ass.Equal(&order{
+			base:  base{ID: 2, Created: now},
+			Audit: &Audit{},
+			Note:  "second",
+		}, orders[1])
+	}
+
+	var o order
+	ass.EqualError(e.Get(ctx, &o, sel), "bsql: missing destination name unknown in executor.order, query: SELECT * FROM orders")
+
+	e.Unsafe = true
+	ass.NoError(e.Get(ctx, &o, sel))
+	ass.Equal(int64(3), o.ID)
+	ass.Equal("amy", o.By)
+	ass.Equal("third", o.Note)
+	ass.Equal("", o.Audit.Note)
+
+	var created time.Time
+	ass.NoError(e.Get(ctx, &created, sel))
+	ass.Equal(now, created)
+
+	var n int
+	ass.Error(e.Get(ctx, &n, sel))
+	ass.Error(e.Get(ctx, n, sel))
+	ass.Error(e.Select(ctx, &n, sel))
+}
+
+type Tree struct {
+	*Tree
+	ID   int64  `db:"id"`
+	Name string `db:"name,omitempty"`
+}
+
+func TestMapper_Recursive(t *testing.T) {
+	ass := assert.New(t)
+
+	db, _ := newFakeDB(fakeRows{cols: []string{"id", "name"}, vals: [][]driver.Value{{int64(1), "root"}}})
+	defer db.Close()
+
+	var tree Tree
+	ass.NoError(New(db, bsql.MySQL).Get(context.Background(), &tree, bsql.Select{Table: bsql.Raw("tree")}))
+	ass.Equal(Tree{ID: 1, Name: "root"}, tree)
+}
@@ -0,0 +1,76 @@
+package bsql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+)
+
+type secExpand struct {
+	query string
+	args  []interface{}
+}
+
+func (e secExpand) Build() (string, []interface{}) {
+	return must(e.BuildE())
+}
+
+func (e secExpand) BuildE() (string, []interface{}, error) {
+	n := 0
+	replacePlaceholders(e.query, false, func(int) string {
+		n++
+		return "?"
+	})
+	if n != len(e.args) {
+		return "", nil, &BuildError{Builder: "ExpandRaw", Err: ErrPlaceholderMismatch}
+	}
+
+	var err error
+	args := make([]interface{}, 0, len(e.args))
+	q := replacePlaceholders(e.query, false, func(n int) string {
+		v := e.args[n-1]
+		rv, ok := expandable(v)
+		if !ok {
+			args = append(args, v)
+			return "?"
+		}
+		if rv.Len() == 0 {
+			err = &BuildError{Builder: "ExpandRaw", Clause: index(n - 1), Err: ErrEmptySlice}
+			return "?"
+		}
+		for i := 0; i < rv.Len(); i++ {
+			args = append(args, rv.Index(i).Interface())
+		}
+		return "?" + strings.Repeat(",?", rv.Len()-1)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q, args, nil
+}
+
+// expandable reports whether v is a slice or an array to be expanded, []byte and driver.Valuer are single values.
+func expandable(v interface{}) (reflect.Value, bool) {
+	if v == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := v.(driver.Valuer); ok {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+// ExpandRaw is Raw expanding the '?' of each slice or array argument into one per element,
+// ExpandRaw("id IN (?)", []int{1, 2, 3}) is Raw("id IN (?,?,?)", 1, 2, 3).
+// []byte and driver.Valuer arguments are kept as is, an empty slice fails to build.
+func ExpandRaw(query string, args ...interface{}) Builder {
+	return secExpand{
+		query: query,
+		args:  args,
+	}
+}
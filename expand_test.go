@@ -0,0 +1,49 @@
+package bsql
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandRaw(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL, ExpandRaw("id IN (?) AND name = ?", []int{1, 2, 3}, "a"),
+			outStruct{"id IN (?,?,?) AND name = ?", []interface{}{1, 2, 3, "a"}},
+		},
+		{
+			PostgreSQL, ExpandRaw("a = ? AND b IN (?) AND c = '?' AND d = ?", []byte("x"), [2]string{"p", "q"}, sql.NullString{}),
+			outStruct{"a = $1 AND b IN ($2,$3) AND c = '?' AND d = $4", []interface{}{[]byte("x"), "p", "q", sql.NullString{}}},
+		},
+		{
+			PostgreSQL, Embed("$ AND $", ExpandRaw("a IN (?)", []interface{}{1, "b"}), Raw("c = ?", 2)),
+			outStruct{"a IN ($1,$2) AND c = $3", []interface{}{1, "b", 2}},
+		},
+		{
+			MySQL, ExpandRaw("a = ?", nil),
+			outStruct{"a = ?", []interface{}{nil}},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	ass.True(errors.Is(Validate(MySQL, ExpandRaw("a IN (?)", []int{})), ErrEmptySlice))
+	ass.True(errors.Is(Validate(MySQL, ExpandRaw("a IN (?)", 1, 2)), ErrPlaceholderMismatch))
+}
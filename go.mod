@@ -1,5 +1,7 @@
 module github.com/forsaken628/bsql
 
+go 1.13
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/jmoiron/sqlx v1.2.0 // indirect
@@ -0,0 +1,117 @@
+package bsql
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// escapeComment keeps s from ending or nesting the comment it is put in,
+// every * and / is percent encoded.
+func escapeComment(s string) string {
+	return strings.NewReplacer("*", "%2A", "/", "%2F").Replace(s)
+}
+
+type secHint struct {
+	b     Builder
+	hints []string
+}
+
+func (h secHint) Build() (string, []interface{}) {
+	return must(h.BuildE())
+}
+
+func (h secHint) BuildE() (string, []interface{}, error) {
+	return h.build(MySQL)
+}
+
+func (h secHint) build(d Dialect) (string, []interface{}, error) {
+	q, a, err := clause(d, "Hint", "", h.b)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(h.hints) == 0 {
+		return q, a, nil
+	}
+
+	for i, v := range h.hints {
+		if strings.ContainsAny(v, "*/") {
+			return "", nil, &BuildError{Builder: "Hint", Clause: index(i), Err: ErrHintText}
+		}
+	}
+
+	switch {
+	case is(d, "sqlserver"):
+		return q + " OPTION (" + strings.Join(h.hints, ",") + ")", a, nil
+	case is(d, "sqlite3"):
+		return "", nil, unsupported("Hint", "", d)
+	}
+
+	hint := "/*+ " + strings.Join(h.hints, " ") + " */"
+	if is(d, "postgres") {
+		return hint + " " + q, a, nil
+	}
+	for _, v := range []string{"SELECT ", "UPDATE ", "INSERT ", "DELETE "} {
+		if strings.HasPrefix(q, v) {
+			return v + hint + " " + q[len(v):], a, nil
+		}
+	}
+	return "", nil, &BuildError{Builder: "Hint", Err: ErrHint}
+}
+
+// Hint adds optimizer hints to the Select, SelectRaw, Update, Insert or Delete b.
+// They follow the verb as /*+ hints */ on MySQL and Oracle, precede the query for pg_hint_plan on PostgreSQL,
+// and are put in OPTION (hints) on SQL Server. A hint can't contain * or /.
+func Hint(b Builder, hints ...string) Builder {
+	return secHint{b: b, hints: hints}
+}
+
+type secComment struct {
+	b    Builder
+	text string
+}
+
+func (c secComment) Build() (string, []interface{}) {
+	return must(c.BuildE())
+}
+
+func (c secComment) BuildE() (string, []interface{}, error) {
+	return c.build(MySQL)
+}
+
+func (c secComment) build(d Dialect) (string, []interface{}, error) {
+	q, a, err := clause(d, "Comment", "", c.b)
+	if err != nil {
+		return "", nil, err
+	}
+	if c.text == "" {
+		return q, a, nil
+	}
+	return q + " /*" + c.text + "*/", a, nil
+}
+
+// Comment appends the comment text to the query b, * and / in text are percent encoded
+// so it can't end the comment early or open a nested one.
+func Comment(b Builder, text string) Builder {
+	return secComment{b: b, text: escapeComment(text)}
+}
+
+// Tags appends tags to the query b as a sqlcommenter comment, /*route='%2Fusers',traceparent='00-...'*/,
+// where the keys are sorted, and the keys and values are URL encoded.
+func Tags(b Builder, tags map[string]string) Builder {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ss := make([]string, len(keys))
+	for i, k := range keys {
+		ss[i] = tagEscape(k) + "='" + tagEscape(tags[k]) + "'"
+	}
+	return secComment{b: b, text: strings.Join(ss, ",")}
+}
+
+func tagEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
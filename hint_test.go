@@ -0,0 +1,93 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHint(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	sel := Select{Table: Raw("t1"), Where: EQ("a", 1)}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL, Hint(sel, "MAX_EXECUTION_TIME(1000)", "NO_INDEX_MERGE(t1)"),
+			outStruct{
+				cond: "SELECT /*+ MAX_EXECUTION_TIME(1000) NO_INDEX_MERGE(t1) */ * FROM t1 WHERE a = ?",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			Oracle, Hint(Update{Table: Raw("t1"), Set: Raw("a = ?", 2), Where: EQ("id", 1)}, "INDEX(t1 idx)"),
+			outStruct{
+				cond: "UPDATE /*+ INDEX(t1 idx) */ t1 SET a = :1 WHERE id = :2",
+				vals: []interface{}{2, 1},
+			},
+		},
+		{
+			MySQL, Hint(Insert{Table: Raw("t1"), Value: Raw("VALUES (?)", 1)}, "SET_VAR(foreign_key_checks=OFF)"),
+			outStruct{
+				cond: "INSERT /*+ SET_VAR(foreign_key_checks=OFF) */ INTO t1 VALUES (?)",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			PostgreSQL, Hint(Delete{Table: Raw("t1"), Where: EQ("a", 1)}, "SeqScan(t1)"),
+			outStruct{
+				cond: "/*+ SeqScan(t1) */ DELETE FROM t1 WHERE a = $1",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLServer, Hint(sel, "MAXDOP 1", "RECOMPILE"),
+			outStruct{
+				cond: "SELECT * FROM t1 WHERE a = @p1 OPTION (MAXDOP 1,RECOMPILE)",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			PostgreSQL, Tags(Hint(sel, "SeqScan(t1)"), map[string]string{"route": "/users/{id}", "action": "it's ?", "traceparent": "00-abc-01"}),
+			outStruct{
+				cond: "/*+ SeqScan(t1) */ SELECT * FROM t1 WHERE a = $1 /*action='it%27s%20%3F',route='%2Fusers%2F%7Bid%7D',traceparent='00-abc-01'*/",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLite, Comment(sel, "job ? */ x"),
+			outStruct{
+				cond: "SELECT * FROM t1 WHERE a = ? /*job ? %2A%2F x*/",
+				vals: []interface{}{1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	q, _ := Build(PostgreSQL, Comment(sel, "a*/*/b"))
+	ass.Equal("SELECT * FROM t1 WHERE a = $1 /*a%2A%2F%2A%2Fb*/", q)
+	q, _ = Build(SQLServer, Tags(sel, map[string]string{"k": "a*/*/b"}))
+	ass.Equal("SELECT * FROM t1 WHERE a = @p1 /*k='a%2A%2F%2A%2Fb'*/", q)
+	ass.True(errors.Is(Validate(MySQL, Hint(sel, "BKA(t1) */ DROP TABLE t1; /*")), ErrHintText))
+	_, _, err := BuildE(SQLServer, Hint(sel, "a*/*/b"))
+	ass.EqualError(err, "bsql: Hint[0]: hint contains * or /")
+
+	_, _, err = BuildE(SQLite, Hint(sel, "x"))
+	ass.True(errors.Is(err, ErrUnsupported))
+	ass.True(errors.Is(Validate(MySQL, Hint(Raw("WITH t AS (SELECT 1) SELECT * FROM t"), "x")), ErrHint))
+	ass.EqualError(Validate(MySQL, Tags(Select{}, nil)), "bsql: Comment: Select.Table: builder is nil")
+}
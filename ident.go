@@ -0,0 +1,82 @@
+package bsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsIdent reports whether name is a plain identifier: a letter or '_' followed by letters, digits, '_' or '$'.
+func IsIdent(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '$'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type secIdent struct {
+	parts  []string
+	strict bool
+}
+
+func (i secIdent) Build() (string, []interface{}) {
+	return must(i.BuildE())
+}
+
+func (i secIdent) BuildE() (string, []interface{}, error) {
+	return i.build(MySQL)
+}
+
+func (i secIdent) build(d Dialect) (string, []interface{}, error) {
+	if len(i.parts) == 0 {
+		return "", nil, &BuildError{Builder: "Ident", Err: ErrInvalidIdent}
+	}
+	qs := make([]string, len(i.parts))
+	for k, v := range i.parts {
+		if v == "*" && k == len(i.parts)-1 && k > 0 {
+			qs[k] = v
+			continue
+		}
+		if i.strict && !IsIdent(v) || v == "" {
+			return "", nil, &BuildError{Builder: "Ident", Err: fmt.Errorf("%w %q", ErrInvalidIdent, strings.Join(i.parts, "."))}
+		}
+		qs[k] = d.QuoteIdent(v)
+	}
+	return strings.Join(qs, "."), nil, nil
+}
+
+// Ident quotes name as a single identifier for the dialect it is built with.
+func Ident(name string) Builder {
+	return secIdent{parts: []string{name}}
+}
+
+// QualifiedIdent quotes each part and joins them with '.', the last part may be "*".
+func QualifiedIdent(parts ...string) Builder {
+	return secIdent{parts: parts}
+}
+
+// StrictIdent is like QualifiedIdent of the '.' separated parts of name,
+// but fails to build unless every part is a plain identifier, see IsIdent.
+// Use it for names coming from user input, such as sort columns.
+func StrictIdent(name string) Builder {
+	return secIdent{
+		parts:  strings.Split(name, "."),
+		strict: true,
+	}
+}
+
+// Idents is a comma separated list of quoted identifiers, for the Fields and GroupBy of SelectRaw.
+func Idents(names ...string) Builder {
+	c := make(SecComma, len(names))
+	for i, v := range names {
+		c[i] = Ident(v)
+	}
+	return c
+}
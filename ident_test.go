@@ -0,0 +1,78 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdent(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	sel := SelectRaw{
+		Fields:  Idents("name", "a`b\"c]d"),
+		Table:   MakeAlias(QualifiedIdent("db", "tb"), "t"),
+		Where:   SecAND{Embed("$ > 1", QualifiedIdent("t", "age")), Raw("x = ?", 1)},
+		OrderBy: Embed("$ DESC", StrictIdent("created_at")),
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL, sel,
+			outStruct{
+				cond: "SELECT `name`,`a``b\"c]d` FROM `db`.`tb` AS t WHERE (`t`.`age` > 1 AND x = ?) ORDER BY `created_at` DESC",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			PostgreSQL, sel,
+			outStruct{
+				cond: `SELECT "name","a` + "`" + `b""c]d" FROM "db"."tb" AS t WHERE ("t"."age" > 1 AND x = $1) ORDER BY "created_at" DESC`,
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLServer, sel,
+			outStruct{
+				cond: "SELECT [name],[a`b\"c]]d] FROM [db].[tb] AS t WHERE ([t].[age] > 1 AND x = @p1) ORDER BY [created_at] DESC",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			Oracle, SelectRaw{Fields: QualifiedIdent("t", "*"), Table: Ident("tb")},
+			outStruct{
+				cond: `SELECT "t".* FROM "tb"`,
+				vals: []interface{}{},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+}
+
+func TestStrictIdent(t *testing.T) {
+	ass := assert.New(t)
+
+	for _, v := range []string{"age", "t.age", "_a1$"} {
+		ass.NoError(Validate(MySQL, StrictIdent(v)), v)
+	}
+	for _, v := range []string{"", "1a", "age desc", "a;drop table t", "t.", "a`b", "$a"} {
+		err := Validate(MySQL, Select{Table: Raw("tb"), Fields: []string{"a"}, Where: Embed("$ = 1", StrictIdent(v))})
+		ass.True(errors.Is(err, ErrInvalidIdent), v)
+	}
+	ass.True(errors.Is(Validate(MySQL, QualifiedIdent()), ErrInvalidIdent))
+	ass.True(errors.Is(Validate(MySQL, Ident("")), ErrInvalidIdent))
+}
@@ -0,0 +1,79 @@
+package bsql
+
+import (
+	"strings"
+)
+
+// SplitArgs splits args into chunks of at most size values,
+// for running a query once per chunk when a list is longer than the database takes placeholders.
+func SplitArgs(args []interface{}, size int) [][]interface{} {
+	if size <= 0 || len(args) <= size {
+		return [][]interface{}{args}
+	}
+	chunks := make([][]interface{}, 0, (len(args)+size-1)/size)
+	for len(args) > size {
+		chunks = append(chunks, args[:size:size])
+		args = args[size:]
+	}
+	return append(chunks, args)
+}
+
+// MakeInChunks is MakeIn with args split into IN lists of at most size values joined by OR,
+// for the databases limiting the length of a list, such as the 1000 of Oracle.
+func MakeInChunks(col string, args []interface{}, size int) Builder {
+	chunks := SplitArgs(args, size)
+	if len(chunks) == 1 {
+		return MakeIn(col, args)
+	}
+	or := make(SecOR, len(chunks))
+	for i, v := range chunks {
+		or[i] = MakeIn(col, v)
+	}
+	return or
+}
+
+type secInValues struct {
+	col  string
+	args []interface{}
+}
+
+func (in secInValues) Build() (string, []interface{}) {
+	return must(in.BuildE())
+}
+
+func (in secInValues) BuildE() (string, []interface{}, error) {
+	return in.build(MySQL)
+}
+
+func (in secInValues) build(d Dialect) (string, []interface{}, error) {
+	if len(in.args) == 0 {
+		return "1=0", nil, nil
+	}
+
+	row := "(?)"
+	if is(d, "mysql") {
+		row = "ROW(?)"
+	}
+	values := "VALUES " + row + strings.Repeat(","+row, len(in.args)-1)
+
+	switch {
+	case is(d, "mysql", "postgres", "sqlite3"):
+		return in.col + " IN (" + values + ")", in.args, nil
+	case is(d, "sqlserver"):
+		return in.col + " IN (SELECT v FROM (" + values + ") AS t(v))", in.args, nil
+	}
+	return "", nil, unsupported("MakeInValues", in.col, d)
+}
+
+func (in secInValues) empty() bool {
+	return len(in.args) == 0
+}
+
+// MakeInValues is MakeIn matching against a VALUES table instead of a list,
+// which large lists are usually better planned with. MySQL requires 8.0.19, Oracle is not supported.
+func MakeInValues(col string, args []interface{}) Builder {
+	return secInValues{
+		col:  col,
+		args: args,
+	}
+}
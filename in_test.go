@@ -0,0 +1,56 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeIn(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{MySQL, MakeIn("a", nil), outStruct{"1=0", nil}},
+		{MySQL, MakeIn("a", []interface{}{}), outStruct{"1=0", nil}},
+		{MySQL, MakeNotIn("a", nil), outStruct{"1=1", nil}},
+		{MySQL, MakeNotIn("a", []interface{}{1, 2}), outStruct{"a NOT IN (?,?)", []interface{}{1, 2}}},
+		{MySQL, Not(MakeIn("a", nil)), outStruct{"NOT (1=0)", nil}},
+		{
+			Oracle, MakeInChunks("a", []interface{}{1, 2, 3, 4, 5}, 2),
+			outStruct{"(a IN (:1,:2) OR a IN (:3,:4) OR a IN (:5))", []interface{}{1, 2, 3, 4, 5}},
+		},
+		{MySQL, MakeInChunks("a", []interface{}{1, 2}, 2), outStruct{"a IN (?,?)", []interface{}{1, 2}}},
+		{MySQL, MakeInChunks("a", nil, 2), outStruct{"1=0", nil}},
+		{MySQL, MakeInValues("a", []interface{}{1, 2}), outStruct{"a IN (VALUES ROW(?),ROW(?))", []interface{}{1, 2}}},
+		{PostgreSQL, MakeInValues("a", []interface{}{1, 2}), outStruct{"a IN (VALUES ($1),($2))", []interface{}{1, 2}}},
+		{SQLServer, MakeInValues("a", []interface{}{1}), outStruct{"a IN (SELECT v FROM (VALUES (@p1)) AS t(v))", []interface{}{1}}},
+		{SQLite, MakeInValues("a", nil), outStruct{"1=0", nil}},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	_, _, err := BuildE(Oracle, MakeInValues("a", []interface{}{1}))
+	ass.True(errors.Is(err, ErrUnsupported))
+	ass.True(IsNull(OmitEmpty(MakeInValues("a", nil))))
+}
+
+func TestSplitArgs(t *testing.T) {
+	ass := assert.New(t)
+	ass.Equal([][]interface{}{{1, 2}, {3, 4}, {5}}, SplitArgs([]interface{}{1, 2, 3, 4, 5}, 2))
+	ass.Equal([][]interface{}{{1, 2}}, SplitArgs([]interface{}{1, 2}, 2))
+	ass.Equal([][]interface{}{{1, 2}}, SplitArgs([]interface{}{1, 2}, 0))
+	ass.Equal([][]interface{}{nil}, SplitArgs(nil, 2))
+}
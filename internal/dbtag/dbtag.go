@@ -0,0 +1,111 @@
+// Package dbtag reads the columns of struct types from their `db` tags.
+package dbtag
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Field is a column of a struct type, named by the `db` tag of the field,
+// or by its lower cased name without a tag. Opts are the options following the name in the tag.
+type Field struct {
+	Name  string
+	Index []int
+	Opts  []string
+}
+
+// Fields returns the columns of the struct type t in declaration order, `db:"-"` fields are skipped.
+//
+// The fields of untagged embedded structs, and of pointers to them, are promoted as Go does:
+// a shallower field hides the deeper ones of the same name, and the fields of the same name
+// at the same depth hide each other. An embedded struct type is walked only once, so recursive
+// types terminate. leaf reports whether an embedded struct type is a single column instead.
+func Fields(t reflect.Type, leaf func(reflect.Type) bool) []Field {
+	type item struct {
+		t     reflect.Type
+		index []int
+	}
+
+	var fs []Field
+	hidden := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+
+	level := []item{{t: t}}
+	for len(level) > 0 {
+		var (
+			next  []item
+			cands []Field
+		)
+		count := make(map[reflect.Type]int)
+		for _, it := range level {
+			count[it.t]++
+		}
+		for _, it := range level {
+			if visited[it.t] {
+				continue
+			}
+			visited[it.t] = true
+
+			for i := 0; i < it.t.NumField(); i++ {
+				f := it.t.Field(i)
+				tag := f.Tag.Get("db")
+				if tag == "-" {
+					continue
+				}
+				index := make([]int, len(it.index)+1)
+				copy(index, it.index)
+				index[len(it.index)] = i
+
+				opts := strings.Split(tag, ",")
+				name := opts[0]
+				if f.Anonymous && name == "" {
+					ft := f.Type
+					if ft.Kind() == reflect.Ptr && f.PkgPath == "" {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct && !leaf(ft) {
+						next = append(next, item{t: ft, index: index})
+						continue
+					}
+				}
+				if f.PkgPath != "" {
+					continue
+				}
+				if name == "" {
+					name = strings.ToLower(f.Name)
+				}
+				cands = append(cands, Field{Name: name, Index: index, Opts: opts[1:]})
+				if count[it.t] > 1 {
+					// the same type embedded twice at this depth, its fields are ambiguous
+					cands = append(cands, Field{Name: name})
+				}
+			}
+		}
+
+		names := make(map[string]int)
+		for _, f := range cands {
+			names[f.Name]++
+		}
+		for _, f := range cands {
+			if !hidden[f.Name] && names[f.Name] == 1 {
+				fs = append(fs, f)
+			}
+		}
+		for name := range names {
+			hidden[name] = true
+		}
+		level = next
+	}
+
+	sort.Slice(fs, func(i, j int) bool {
+		a, b := fs[i].Index, fs[j].Index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fs
+}
@@ -0,0 +1,185 @@
+package bsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+type joinItem struct {
+	typ     int8
+	natural bool
+	lateral bool
+	table   Builder
+	on      Builder
+	using   []string
+}
+
+// Joins is a FROM clause joining tables one after another, it is made by From,
+// From(Raw("a")).Join(Raw("b"), Raw("b.a_id = a.id")).LeftJoinUsing(Raw("c"), "id").
+// Every method returns a new Joins, so a common part can be shared by several queries.
+type Joins struct {
+	table Builder
+	joins []joinItem
+}
+
+func From(table Builder) Joins {
+	return Joins{table: table}
+}
+
+func (j Joins) add(v joinItem) Joins {
+	j.joins = append(j.joins[:len(j.joins):len(j.joins)], v)
+	return j
+}
+
+// JoinOn joins table of the join type typ, InnerJoin, LeftJoin, RightJoin, FullJoin or CrossJoin, on the condition on.
+func (j Joins) JoinOn(typ int8, table, on Builder) Joins {
+	return j.add(joinItem{typ: typ, table: table, on: on})
+}
+
+// JoinUsing joins table of the join type typ on the equality of cols.
+func (j Joins) JoinUsing(typ int8, table Builder, cols ...string) Joins {
+	return j.add(joinItem{typ: typ, table: table, using: cols})
+}
+
+// Natural joins table of the join type typ on the equality of their common columns.
+func (j Joins) Natural(typ int8, table Builder) Joins {
+	return j.add(joinItem{typ: typ, natural: true, table: table})
+}
+
+// Lateral joins the subquery sub named alias, which may refer to the columns of the preceding tables,
+// on the condition on, or on every row if on is nil.
+// It is CROSS APPLY or OUTER APPLY on SQL Server.
+func (j Joins) Lateral(typ int8, sub Builder, alias string, on Builder) Joins {
+	return j.add(joinItem{typ: typ, lateral: true, table: secLateral{sub: sub, alias: alias}, on: on})
+}
+
+func (j Joins) Join(table, on Builder) Joins {
+	return j.JoinOn(InnerJoin, table, on)
+}
+
+func (j Joins) LeftJoin(table, on Builder) Joins {
+	return j.JoinOn(LeftJoin, table, on)
+}
+
+func (j Joins) RightJoin(table, on Builder) Joins {
+	return j.JoinOn(RightJoin, table, on)
+}
+
+func (j Joins) FullJoin(table, on Builder) Joins {
+	return j.JoinOn(FullJoin, table, on)
+}
+
+func (j Joins) CrossJoin(table Builder) Joins {
+	return j.JoinOn(CrossJoin, table, nil)
+}
+
+func (j Joins) Build() (string, []interface{}) {
+	return must(j.BuildE())
+}
+
+func (j Joins) BuildE() (string, []interface{}, error) {
+	return j.build(MySQL)
+}
+
+func (j Joins) build(d Dialect) (string, []interface{}, error) {
+	q, args, err := clause(d, "From", "Table", j.table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	b := strings.Builder{}
+	b.WriteString(q)
+	for i, v := range j.joins {
+		q, a, err := v.build(d)
+		if err != nil {
+			return "", nil, &BuildError{Builder: "From", Clause: index(i), Err: err}
+		}
+		b.WriteString(q)
+		args = append(args, a...)
+	}
+	return b.String(), args, nil
+}
+
+func (v joinItem) build(d Dialect) (string, []interface{}, error) {
+	join, ok := joinKeyword(v.typ)
+	switch {
+	case !ok || v.natural && v.typ == CrossJoin:
+		return "", nil, &BuildError{Builder: "Join", Err: fmt.Errorf("%w %d", ErrJoinType, v.typ)}
+	case v.typ == FullJoin && is(d, "mysql"):
+		return "", nil, unsupported("Join", "FullJoin", d)
+	case v.natural && is(d, "sqlserver"):
+		return "", nil, unsupported("Join", "Natural", d)
+	case v.using != nil && is(d, "sqlserver"):
+		return "", nil, unsupported("Join", "Using", d)
+	case v.lateral && is(d, "sqlite3"):
+		return "", nil, unsupported("Join", "Lateral", d)
+	case v.typ == CrossJoin && v.on != nil:
+		return "", nil, &BuildError{Builder: "Join", Clause: "On", Err: fmt.Errorf("%w, CROSS JOIN has no condition", ErrJoinCondition)}
+	case v.typ != CrossJoin && !v.natural && !v.lateral && v.on == nil && v.using == nil:
+		return "", nil, &BuildError{Builder: "Join", Clause: "On", Err: fmt.Errorf("%w, %s needs ON or USING", ErrJoinCondition, join)}
+	case v.using != nil && len(v.using) == 0:
+		return "", nil, &BuildError{Builder: "Join", Clause: "Using", Err: fmt.Errorf("%w, USING needs columns", ErrJoinCondition)}
+	}
+
+	table, args, err := clause(d, "Join", "Table", v.table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if v.lateral && is(d, "sqlserver") {
+		switch {
+		case v.on == nil && (v.typ == InnerJoin || v.typ == CrossJoin):
+			return " CROSS APPLY " + table, args, nil
+		case v.on == nil && v.typ == LeftJoin:
+			return " OUTER APPLY " + table, args, nil
+		}
+		return "", nil, unsupported("Join", "Lateral", d)
+	}
+
+	if v.natural {
+		join = "NATURAL " + join
+	}
+	if v.lateral {
+		table = "LATERAL " + table
+	}
+	q := " " + join + " " + table
+
+	switch {
+	case v.on != nil:
+		on, a, err := clause(d, "Join", "On", v.on)
+		if err != nil {
+			return "", nil, err
+		}
+		q += " ON " + on
+		args = append(args, a...)
+	case v.using != nil:
+		q += " USING (" + strings.Join(v.using, ",") + ")"
+	case v.lateral && v.typ != CrossJoin:
+		q += " ON 1=1"
+	}
+	return q, args, nil
+}
+
+type secLateral struct {
+	sub   Builder
+	alias string
+}
+
+func (l secLateral) Build() (string, []interface{}) {
+	return must(l.BuildE())
+}
+
+func (l secLateral) BuildE() (string, []interface{}, error) {
+	return l.build(MySQL)
+}
+
+func (l secLateral) build(d Dialect) (string, []interface{}, error) {
+	if l.alias == "" {
+		return "", nil, &BuildError{Builder: "Lateral", Clause: "Alias", Err: ErrInvalidIdent}
+	}
+	q, a, err := clause(d, "Lateral", "", l.sub)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + q + ") " + l.alias, a, nil
+}
@@ -0,0 +1,93 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoins(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	base := From(MakeAlias(Raw("users"), "u")).
+		Join(MakeAlias(Raw("orders"), "o"), Raw("o.user_id = u.id AND o.status = ?", 1))
+
+	last := Select{Fields: []string{"amount"}, Table: Raw("orders o2"), Where: Raw("o2.user_id = u.id"), OrderBy: []string{"id DESC"}, Limit: []uint{1}}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			Select{
+				Table: base.
+					LeftJoin(MakeAlias(Raw("items"), "i"), Raw("i.order_id = o.id")).
+					RightJoin(Raw("shops s"), Raw("s.id = o.shop_id")).
+					FullJoin(Raw("refunds r"), Raw("r.order_id = o.id")).
+					JoinUsing(InnerJoin, Raw("regions"), "region_id", "country").
+					CrossJoin(Raw("config")),
+				Where: EQ("u.id", 2),
+			},
+			outStruct{
+				cond: "SELECT * FROM users AS u JOIN orders AS o ON o.user_id = u.id AND o.status = $1 LEFT JOIN items AS i ON i.order_id = o.id RIGHT JOIN shops s ON s.id = o.shop_id FULL OUTER JOIN refunds r ON r.order_id = o.id JOIN regions USING (region_id,country) CROSS JOIN config WHERE u.id = $2",
+				vals: []interface{}{1, 2},
+			},
+		},
+		{
+			MySQL,
+			base.Natural(LeftJoin, Raw("profiles")).Lateral(LeftJoin, last, "l", nil),
+			outStruct{
+				cond: "users AS u JOIN orders AS o ON o.user_id = u.id AND o.status = ? NATURAL LEFT JOIN profiles LEFT JOIN LATERAL (SELECT amount FROM orders o2 WHERE o2.user_id = u.id ORDER BY id DESC LIMIT ?) l ON 1=1",
+				vals: []interface{}{1, uint(1)},
+			},
+		},
+		{
+			PostgreSQL,
+			From(Raw("users u")).Lateral(CrossJoin, last, "l", nil).Lateral(InnerJoin, last, "m", Raw("m.amount > ?", 5)),
+			outStruct{
+				cond: "users u CROSS JOIN LATERAL (SELECT amount FROM orders o2 WHERE o2.user_id = u.id ORDER BY id DESC LIMIT $1) l JOIN LATERAL (SELECT amount FROM orders o2 WHERE o2.user_id = u.id ORDER BY id DESC LIMIT $2) m ON m.amount > $3",
+				vals: []interface{}{uint(1), uint(1), 5},
+			},
+		},
+		{
+			SQLServer,
+			From(Raw("users u")).Lateral(CrossJoin, last, "l", nil).Lateral(LeftJoin, last, "m", nil),
+			outStruct{
+				cond: "users u CROSS APPLY (SELECT TOP (@p1) amount FROM orders o2 WHERE o2.user_id = u.id ORDER BY id DESC) l OUTER APPLY (SELECT TOP (@p2) amount FROM orders o2 WHERE o2.user_id = u.id ORDER BY id DESC) m",
+				vals: []interface{}{uint(1), uint(1)},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	ass.EqualError(Validate(MySQL, base.JoinOn(CrossJoin, Raw("t"), Raw("1=1"))), "bsql: From[1]: Join.On: invalid join condition, CROSS JOIN has no condition")
+	ass.EqualError(Validate(MySQL, base.LeftJoin(Raw("t"), nil)), "bsql: From[1]: Join.On: invalid join condition, LEFT JOIN needs ON or USING")
+	ass.True(errors.Is(Validate(MySQL, base.JoinUsing(InnerJoin, Raw("t"))), ErrJoinCondition))
+	ass.True(errors.Is(Validate(MySQL, base.Natural(CrossJoin, Raw("t"))), ErrJoinType))
+	ass.True(errors.Is(Validate(MySQL, base.FullJoin(Raw("t"), Raw("1=1"))), ErrUnsupported))
+	ass.EqualError(Validate(MySQL, From(nil)), "bsql: From.Table: builder is nil")
+	ass.EqualError(Validate(MySQL, base.Join(nil, Raw("1=1"))), "bsql: From[1]: Join.Table: builder is nil")
+
+	for _, b := range []Builder{
+		base.Natural(InnerJoin, Raw("t")),
+		base.JoinUsing(InnerJoin, Raw("t"), "id"),
+		base.Lateral(RightJoin, last, "l", nil),
+	} {
+		_, _, err := BuildE(SQLServer, b)
+		ass.True(errors.Is(err, ErrUnsupported))
+	}
+	_, _, err := BuildE(SQLite, base.Lateral(CrossJoin, last, "l", nil))
+	ass.True(errors.Is(err, ErrUnsupported))
+}
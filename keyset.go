@@ -0,0 +1,267 @@
+package bsql
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// orderKey is an item of Select.OrderBy, "col [ASC|DESC] [NULLS FIRST|NULLS LAST]".
+type orderKey struct {
+	col   string
+	desc  bool
+	nulls string
+}
+
+func parseOrder(item string) (orderKey, error) {
+	ss := strings.Fields(item)
+	if len(ss) == 0 {
+		return orderKey{}, fmt.Errorf("%w order %q", ErrCursor, item)
+	}
+	k := orderKey{col: ss[0]}
+	rest := ss[1:]
+	if len(rest) > 0 {
+		switch strings.ToUpper(rest[0]) {
+		case "ASC":
+			rest = rest[1:]
+		case "DESC":
+			k.desc = true
+			rest = rest[1:]
+		}
+	}
+	if len(rest) == 2 && strings.ToUpper(rest[0]) == "NULLS" {
+		k.nulls = strings.ToUpper(rest[1])
+		rest = nil
+	}
+	if len(rest) > 0 || k.nulls != "" && k.nulls != "FIRST" && k.nulls != "LAST" {
+		return orderKey{}, fmt.Errorf("%w order %q", ErrCursor, item)
+	}
+	return k, nil
+}
+
+func (k orderKey) String() string {
+	s := k.col + " ASC"
+	if k.desc {
+		s = k.col + " DESC"
+	}
+	if k.nulls != "" {
+		s += " NULLS " + k.nulls
+	}
+	return s
+}
+
+// reverse is the key ordering the rows backwards.
+func (k orderKey) reverse() orderKey {
+	k.desc = !k.desc
+	switch k.nulls {
+	case "FIRST":
+		k.nulls = "LAST"
+	case "LAST":
+		k.nulls = "FIRST"
+	}
+	return k
+}
+
+// nullsFirst reports whether NULL is ordered before the other values of the key on d,
+// NULL is the smallest value on MySQL, SQLite and SQL Server, and the largest elsewhere.
+func (k orderKey) nullsFirst(d Dialect) bool {
+	if k.nulls != "" {
+		return k.nulls == "FIRST"
+	}
+	return is(d, "mysql", "sqlite3", "sqlserver") != k.desc
+}
+
+type secSeek struct {
+	orderBy  []string
+	nullable []string
+	values   []interface{}
+}
+
+func (s secSeek) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s secSeek) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s secSeek) build(d Dialect) (string, []interface{}, error) {
+	if len(s.orderBy) == 0 || len(s.orderBy) != len(s.values) {
+		return "", nil, &BuildError{Builder: "Seek", Err: fmt.Errorf("%w, %d values for %d columns", ErrCursor, len(s.values), len(s.orderBy))}
+	}
+
+	keys := make([]orderKey, len(s.orderBy))
+	nullable := make([]bool, len(s.orderBy))
+	row := is(d, "mysql", "postgres", "sqlite3")
+	for i, v := range s.orderBy {
+		k, err := parseOrder(v)
+		if err != nil {
+			return "", nil, &BuildError{Builder: "Seek", Clause: index(i), Err: err}
+		}
+		keys[i] = k
+		nullable[i] = k.nulls != ""
+		for _, c := range s.nullable {
+			nullable[i] = nullable[i] || c == k.col
+		}
+		row = row && k.desc == keys[0].desc && !nullable[i] && !isNil(s.values[i])
+	}
+
+	if row && len(keys) > 1 {
+		cols := make([]string, len(keys))
+		for i, k := range keys {
+			cols[i] = k.col
+		}
+		op := ") > ("
+		if keys[0].desc {
+			op = ") < ("
+		}
+		return "(" + strings.Join(cols, ",") + op + strings.Repeat(",?", len(keys))[1:] + ")", s.values, nil
+	}
+
+	var (
+		or SecOR
+		eq SecAND
+	)
+	term := func(b Builder) Builder {
+		if len(eq) == 0 {
+			return b
+		}
+		return append(eq[:len(eq):len(eq)], b)
+	}
+	for i, k := range keys {
+		v := s.values[i]
+		op := " > ?"
+		if k.desc {
+			op = " < ?"
+		}
+		switch {
+		case isNil(v):
+			if k.nullsFirst(d) {
+				or = append(or, term(NotNull(k.col)))
+			}
+			eq = append(eq, Null(k.col))
+			continue
+		case nullable[i] && !k.nullsFirst(d):
+			// the NULLs are after v
+			or = append(or, term(SecOR{Raw(k.col+op, v), Null(k.col)}))
+		default:
+			or = append(or, term(Raw(k.col+op, v)))
+		}
+		eq = append(eq, Raw(k.col+" = ?", v))
+	}
+	if len(or) == 0 {
+		return "1=0", nil, nil
+	}
+	return or.build(d)
+}
+
+// Seek is the predicate selecting the rows after the row whose orderBy columns have values,
+// each orderBy item is "col [ASC|DESC] [NULLS FIRST|NULLS LAST]" as in Select.OrderBy.
+//
+// A column is taken as nullable if its item states NULLS FIRST or NULLS LAST or its value is nil,
+// the others are taken as NOT NULL, see SeekNullable. If every column is NOT NULL and sorted in the same direction,
+// the predicate is a row comparison (a,b) > (?,?) on MySQL, PostgreSQL and SQLite.
+func Seek(orderBy []string, values ...interface{}) Builder {
+	return secSeek{orderBy: orderBy, values: values}
+}
+
+// SeekNullable is Seek taking the columns nullable as nullable too, so the rows where they are NULL
+// are not skipped, for the dialects which don't accept NULLS FIRST or NULLS LAST in ORDER BY.
+func SeekNullable(orderBy, nullable []string, values ...interface{}) Builder {
+	return secSeek{orderBy: orderBy, nullable: nullable, values: values}
+}
+
+// After returns s limited to the rows after the row with values of its OrderBy columns.
+func (s Select) After(values ...interface{}) Select {
+	return s.after(nil, values)
+}
+
+func (s Select) after(nullable []string, values []interface{}) Select {
+	s.Where = andWhere(s.Where, SeekNullable(s.OrderBy, nullable, values...))
+	return s
+}
+
+// Before returns s limited to the rows before the row with values of its OrderBy columns,
+// the rows are ordered backwards so Limit takes the nearest ones, reverse them to restore the order.
+func (s Select) Before(values ...interface{}) Select {
+	return s.before(nil, values)
+}
+
+func (s Select) before(nullable []string, values []interface{}) Select {
+	orderBy := make([]string, len(s.OrderBy))
+	for i, v := range s.OrderBy {
+		k, err := parseOrder(v)
+		if err != nil {
+			return s.after(nullable, values)
+		}
+		orderBy[i] = k.reverse().String()
+	}
+	s.OrderBy = orderBy
+	s.Where = andWhere(s.Where, SeekNullable(orderBy, nullable, values...))
+	return s
+}
+
+// Seek returns s limited to the rows the cursor c points to, the OrderBy columns nullable are taken as nullable,
+// see SeekNullable.
+func (s Select) Seek(c Cursor, nullable ...string) Select {
+	if c.Backward {
+		return s.before(nullable, c.Values)
+	}
+	return s.after(nullable, c.Values)
+}
+
+func andWhere(where, b Builder) Builder {
+	if where == nil {
+		return b
+	}
+	return SecAND{where, b}
+}
+
+// Cursor is the position of keyset pagination, Values are the OrderBy columns of the last row of a page,
+// or of the first row if Backward.
+type Cursor struct {
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// Encode encodes c into an opaque URL safe token.
+func (c Cursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes the token made by Cursor.Encode.
+// Integers are decoded as int64, other numbers as float64 and times as strings.
+func DecodeCursor(token string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("%w: %v", ErrCursor, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil {
+		return c, fmt.Errorf("%w: %v", ErrCursor, err)
+	}
+	if len(c.Values) == 0 {
+		return c, ErrCursor
+	}
+	for i, v := range c.Values {
+		switch n := v.(type) {
+		case json.Number:
+			if x, err := n.Int64(); err == nil {
+				c.Values[i] = x
+			} else if x, err := n.Float64(); err == nil {
+				c.Values[i] = x
+			}
+		case map[string]interface{}, []interface{}:
+			return c, fmt.Errorf("%w: value %d is not a scalar", ErrCursor, i)
+		}
+	}
+	return c, nil
+}
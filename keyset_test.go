@@ -0,0 +1,120 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeek(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	sel := Select{Table: Raw("tb"), Where: EQ("a", 1), OrderBy: []string{"created_at DESC", "id DESC"}, Limit: []uint{10}}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL, sel.After("2020-01-01", 7),
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (a = $1 AND (created_at,id) < ($2,$3)) ORDER BY created_at DESC,id DESC LIMIT $4",
+				vals: []interface{}{1, "2020-01-01", 7, uint(10)},
+			},
+		},
+		{
+			PostgreSQL, sel.Before("2020-01-01", 7),
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (a = $1 AND (created_at,id) > ($2,$3)) ORDER BY created_at ASC,id ASC LIMIT $4",
+				vals: []interface{}{1, "2020-01-01", 7, uint(10)},
+			},
+		},
+		{
+			SQLServer, Seek([]string{"name", "id DESC"}, "bob", 7),
+			outStruct{
+				cond: "(name > @p1 OR (name = @p2 AND id < @p3))",
+				vals: []interface{}{"bob", "bob", 7},
+			},
+		},
+		{
+			PostgreSQL, Seek([]string{"score DESC NULLS LAST", "id"}, 5, 7),
+			outStruct{
+				cond: "((score < $1 OR score IS NULL) OR (score = $2 AND id > $3))",
+				vals: []interface{}{5, 5, 7},
+			},
+		},
+		{
+			MySQL, Seek([]string{"score", "id"}, nil, 7),
+			outStruct{
+				cond: "(score IS NOT NULL OR (score IS NULL AND id > ?))",
+				vals: []interface{}{7},
+			},
+		},
+		{
+			PostgreSQL, Seek([]string{"score", "id"}, nil, 7),
+			outStruct{
+				cond: "((score IS NULL AND id > $1))",
+				vals: []interface{}{7},
+			},
+		},
+		{
+			MySQL, SeekNullable([]string{"score DESC", "id DESC"}, []string{"score"}, 5, 10),
+			outStruct{
+				cond: "((score < ? OR score IS NULL) OR (score = ? AND id < ?))",
+				vals: []interface{}{5, 5, 10},
+			},
+		},
+		{
+			SQLServer, Select{Table: Raw("tb"), OrderBy: []string{"score DESC", "id DESC"}}.Seek(Cursor{Values: []interface{}{5, 10}, Backward: true}, "score"),
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (score > @p1 OR (score = @p2 AND id > @p3)) ORDER BY score ASC,id ASC",
+				vals: []interface{}{5, 5, 10},
+			},
+		},
+		{
+			MySQL, Select{Table: Raw("tb"), OrderBy: []string{"id"}}.Seek(Cursor{Values: []interface{}{3}, Backward: true}),
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (id < ?) ORDER BY id DESC",
+				vals: []interface{}{3},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	ass.True(errors.Is(Validate(MySQL, sel.After(1)), ErrCursor))
+	ass.EqualError(Validate(MySQL, Seek([]string{"id sideways"}, 1)), `bsql: Seek[0]: invalid cursor order "id sideways"`)
+	q, a := Build(PostgreSQL, Seek([]string{"a NULLS LAST"}, nil))
+	ass.Equal("1=0", q)
+	ass.Empty(a)
+}
+
+func TestCursor(t *testing.T) {
+	ass := assert.New(t)
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	token, err := Cursor{Values: []interface{}{now, 42, 1.5, "a", nil, true}, Backward: true}.Encode()
+	ass.NoError(err)
+	ass.NotContains(token, "=")
+
+	c, err := DecodeCursor(token)
+	ass.NoError(err)
+	ass.True(c.Backward)
+	ass.Equal([]interface{}{"2020-01-02T03:04:05Z", int64(42), 1.5, "a", nil, true}, c.Values)
+
+	for _, v := range []string{"", "!!", "e30", "eyJ2IjpbW11dfQ"} {
+		_, err := DecodeCursor(v)
+		ass.True(errors.Is(err, ErrCursor), v)
+	}
+}
@@ -0,0 +1,78 @@
+package bsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ForUpdate = iota
+	ForShare
+	ForNoKeyUpdate
+	ForKeyShare
+	// LockInShareMode is the FOR SHARE of MySQL before 8.0, it takes no Of, NoWait or SkipLocked.
+	LockInShareMode
+)
+
+// Lock is the row locking clause of Select and SelectRaw, SELECT ... FOR UPDATE OF t SKIP LOCKED.
+//
+// MySQL accepts all the modes but ForNoKeyUpdate and ForKeyShare, PostgreSQL all but LockInShareMode,
+// Oracle only ForUpdate, whose Of lists columns instead of tables. SQLite and SQL Server are not supported.
+type Lock struct {
+	Mode       int8
+	Of         []string
+	NoWait     bool
+	SkipLocked bool
+}
+
+func (l Lock) Build() (string, []interface{}) {
+	return must(l.BuildE())
+}
+
+func (l Lock) BuildE() (string, []interface{}, error) {
+	return l.build(MySQL)
+}
+
+func (l Lock) build(d Dialect) (string, []interface{}, error) {
+	if is(d, "sqlite3", "sqlserver") {
+		return "", nil, unsupported("Lock", "", d)
+	}
+
+	q := ""
+	switch l.Mode {
+	case ForUpdate:
+		q = "FOR UPDATE"
+	case ForShare:
+		q = "FOR SHARE"
+	case ForNoKeyUpdate:
+		q = "FOR NO KEY UPDATE"
+	case ForKeyShare:
+		q = "FOR KEY SHARE"
+	case LockInShareMode:
+		q = "LOCK IN SHARE MODE"
+	default:
+		return "", nil, &BuildError{Builder: "Lock", Clause: "Mode", Err: fmt.Errorf("%w mode %d", ErrLock, l.Mode)}
+	}
+
+	switch {
+	case is(d, "mysql") && (l.Mode == ForNoKeyUpdate || l.Mode == ForKeyShare),
+		is(d, "postgres") && l.Mode == LockInShareMode,
+		is(d, "oracle") && l.Mode != ForUpdate:
+		return "", nil, unsupported("Lock", q, d)
+	case l.Mode == LockInShareMode && (l.Of != nil || l.NoWait || l.SkipLocked):
+		return "", nil, &BuildError{Builder: "Lock", Err: fmt.Errorf("%w, LOCK IN SHARE MODE takes no options", ErrLock)}
+	case l.NoWait && l.SkipLocked:
+		return "", nil, &BuildError{Builder: "Lock", Err: fmt.Errorf("%w, NOWAIT with SKIP LOCKED", ErrLock)}
+	}
+
+	if len(l.Of) > 0 {
+		q += " OF " + strings.Join(l.Of, ",")
+	}
+	if l.NoWait {
+		q += " NOWAIT"
+	}
+	if l.SkipLocked {
+		q += " SKIP LOCKED"
+	}
+	return q, nil, nil
+}
@@ -0,0 +1,94 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLock(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	jobs := Select{
+		Fields:  []string{"id"},
+		Table:   Raw("jobs"),
+		Where:   EQ("status", 0),
+		OrderBy: []string{"id"},
+		Limit:   []uint{10},
+		Lock:    &Lock{Mode: ForUpdate, SkipLocked: true},
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL, jobs,
+			outStruct{
+				cond: "SELECT id FROM jobs WHERE status = $1 ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED",
+				vals: []interface{}{0, uint(10)},
+			},
+		},
+		{
+			MySQL, jobs,
+			outStruct{
+				cond: "SELECT id FROM jobs WHERE status = ? ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED",
+				vals: []interface{}{0, uint(10)},
+			},
+		},
+		{
+			PostgreSQL,
+			SelectRaw{Fields: Raw("*"), Table: Raw("accounts a JOIN users u ON u.id = a.user_id"), Where: EQ("a.id", 1), Lock: &Lock{Mode: ForNoKeyUpdate, Of: []string{"a"}, NoWait: true}},
+			outStruct{
+				cond: "SELECT * FROM accounts a JOIN users u ON u.id = a.user_id WHERE a.id = $1 FOR NO KEY UPDATE OF a NOWAIT",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			MySQL, Select{Table: Raw("accounts"), Lock: &Lock{Mode: LockInShareMode}},
+			outStruct{
+				cond: "SELECT * FROM accounts LOCK IN SHARE MODE",
+				vals: []interface{}{},
+			},
+		},
+		{
+			Oracle, Select{Table: Raw("accounts"), Where: EQ("id", 1), Lock: &Lock{Of: []string{"balance"}, NoWait: true}},
+			outStruct{
+				cond: "SELECT * FROM accounts WHERE id = :1 FOR UPDATE OF balance NOWAIT",
+				vals: []interface{}{1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	var errs = []struct {
+		d    Dialect
+		lock Lock
+		err  error
+	}{
+		{SQLite, Lock{}, ErrUnsupported},
+		{SQLServer, Lock{}, ErrUnsupported},
+		{MySQL, Lock{Mode: ForKeyShare}, ErrUnsupported},
+		{PostgreSQL, Lock{Mode: LockInShareMode}, ErrUnsupported},
+		{Oracle, Lock{Mode: ForShare}, ErrUnsupported},
+		{MySQL, Lock{Mode: LockInShareMode, NoWait: true}, ErrLock},
+		{PostgreSQL, Lock{NoWait: true, SkipLocked: true}, ErrLock},
+		{PostgreSQL, Lock{Mode: 9}, ErrLock},
+	}
+	for _, tc := range errs {
+		_, _, err := BuildE(tc.d, Select{Table: Raw("t"), Lock: &tc.lock})
+		ass.True(errors.Is(err, tc.err), "%v", err)
+	}
+	ass.EqualError(Validate(MySQL, Select{Table: Raw("t"), Lock: &Lock{NoWait: true, SkipLocked: true}}), "bsql: Select.Lock: Lock: invalid lock, NOWAIT with SKIP LOCKED")
+}
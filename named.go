@@ -0,0 +1,127 @@
+package bsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type secNamed struct {
+	query string
+	arg   interface{}
+}
+
+func (n secNamed) Build() (string, []interface{}) {
+	return must(n.BuildE())
+}
+
+func (n secNamed) BuildE() (string, []interface{}, error) {
+	return n.build(MySQL)
+}
+
+func (n secNamed) build(d Dialect) (string, []interface{}, error) {
+	values, strict, err := namedValues(n.arg)
+	if err != nil {
+		return "", nil, &BuildError{Builder: "NamedRaw", Err: err}
+	}
+
+	used := make(map[string]bool)
+	args := make([]interface{}, 0)
+	missing := ""
+
+	q := rewriteSQL(n.query, false, func(i int) (string, int) {
+		c := n.query[i]
+		if c != ':' && c != '@' {
+			return "", 0
+		}
+		// skip casts ::, variables @@ and anything glued to a word
+		if i > 0 && (n.query[i-1] == ':' || n.query[i-1] == '@' || isIdentByte(n.query[i-1])) {
+			return "", 0
+		}
+		j := i + 1
+		if j >= len(n.query) || !isIdentStart(n.query[j]) {
+			return "", 0
+		}
+		for j < len(n.query) && isIdentByte(n.query[j]) {
+			j++
+		}
+		name := n.query[i+1 : j]
+
+		v, ok := values[name]
+		if !ok {
+			if missing == "" {
+				missing = name
+			}
+			return "?", j - i
+		}
+
+		used[name] = true
+		args = append(args, v)
+		return "?", j - i
+	})
+
+	if missing != "" {
+		return "", nil, &BuildError{Builder: "NamedRaw", Err: fmt.Errorf("%w %s", ErrMissingName, missing)}
+	}
+	if strict && len(used) != len(values) {
+		unused := make([]string, 0)
+		for k := range values {
+			if !used[k] {
+				unused = append(unused, k)
+			}
+		}
+		sort.Strings(unused)
+		return "", nil, &BuildError{Builder: "NamedRaw", Err: fmt.Errorf("%w %v", ErrUnusedName, unused)}
+	}
+
+	return q, args, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// namedValues returns the values of arg by name, strict is set if every one of them must be used.
+func namedValues(arg interface{}) (map[string]interface{}, bool, error) {
+	values := make(map[string]interface{})
+	if arg == nil {
+		return values, true, nil
+	}
+
+	rv := reflect.ValueOf(arg)
+	if rv.Kind() == reflect.Map {
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false, errors.New("named arg map key is not string")
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = iter.Value().Interface()
+		}
+		return values, true, nil
+	}
+
+	sv, ok := structValue(arg)
+	if !ok {
+		return nil, false, errors.New("named arg is not map or struct")
+	}
+	for _, f := range structFieldsOf(sv.Type()) {
+		values[f.name] = valueOf(fieldOf(sv, f.index))
+	}
+	return values, false, nil
+}
+
+// NamedRaw is Raw with :name or @name parameters taken from arg,
+// a map with string keys or a struct named as MakeValuesStruct does.
+// They become positional parameters on every dialect, so NamedRaw composes with the other builders.
+// A name missing from arg fails to build, so does a key of the map which is not used.
+func NamedRaw(query string, arg interface{}) Builder {
+	return secNamed{
+		query: query,
+		arg:   arg,
+	}
+}
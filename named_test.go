@@ -0,0 +1,86 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedRaw(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	type report struct {
+		Tenant int    `db:"tenant"`
+		Start  string `db:"start"`
+		End    string
+		Other  string
+	}
+
+	query := "tenant = @tenant AND day BETWEEN :start AND :end AND note <> ':start' AND x::int > 0 AND @@autocommit = 1 AND owner = @tenant"
+	m := map[string]interface{}{"tenant": 7, "start": "2020-01-01", "end": "2020-02-01"}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL, NamedRaw(query, m),
+			outStruct{
+				cond: "tenant = ? AND day BETWEEN ? AND ? AND note <> ':start' AND x::int > 0 AND @@autocommit = 1 AND owner = ?",
+				vals: []interface{}{7, "2020-01-01", "2020-02-01", 7},
+			},
+		},
+		{
+			PostgreSQL, SecAND{EQ("a", 1), NamedRaw(query, &report{Tenant: 7, Start: "2020-01-01", End: "2020-02-01"})},
+			outStruct{
+				cond: "(a = $1 AND tenant = $2 AND day BETWEEN $3 AND $4 AND note <> ':start' AND x::int > 0 AND @@autocommit = 1 AND owner = $5)",
+				vals: []interface{}{1, 7, "2020-01-01", "2020-02-01", 7},
+			},
+		},
+		{
+			SQLServer, SecAND{EQ("a", 1), NamedRaw("b = @tenant OR c = :tenant", map[string]interface{}{"tenant": 7}), EQ("d", 2)},
+			outStruct{
+				cond: "(a = @p1 AND b = @p2 OR c = @p3 AND d = @p4)",
+				vals: []interface{}{1, 7, 7, 2},
+			},
+		},
+		{
+			SQLServer, SecAND{NamedRaw("a = @x", map[string]interface{}{"x": 1}), EQ("c", 2)},
+			outStruct{
+				cond: "(a = @p1 AND c = @p2)",
+				vals: []interface{}{1, 2},
+			},
+		},
+		{
+			Oracle, SecAND{EQ("c", 2), NamedRaw("a = :x", map[string]int{"x": 1})},
+			outStruct{
+				cond: "(c = :1 AND a = :2)",
+				vals: []interface{}{2, 1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	err := Validate(MySQL, NamedRaw("a = :a AND b = :b", map[string]interface{}{"a": 1}))
+	ass.True(errors.Is(err, ErrMissingName))
+	ass.EqualError(err, "bsql: NamedRaw: missing named parameter b")
+
+	err = Validate(MySQL, NamedRaw("a = :a", map[string]interface{}{"a": 1, "c": 2, "b": 3}))
+	ass.True(errors.Is(err, ErrUnusedName))
+	ass.EqualError(err, "bsql: NamedRaw: unused named parameter [b c]")
+
+	ass.Error(Validate(MySQL, NamedRaw("a = :a", 1)))
+	ass.Error(Validate(MySQL, NamedRaw("a = :a", map[int]int{1: 1})))
+	ass.NoError(Validate(MySQL, NamedRaw("a = 1", nil)))
+}
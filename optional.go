@@ -0,0 +1,130 @@
+package bsql
+
+import (
+	"reflect"
+)
+
+// emptier is implemented by the builders which can tell they are given no values, such as MakeIn.
+type emptier interface {
+	empty() bool
+}
+
+// secOptional is a Nullable wrapper of b, so that SecAND and SecOR skip it when it is null.
+type secOptional struct {
+	b    Builder
+	skip bool
+	// omitted reports whether an argument of b counts as missing, b is null if it has arguments and all of them are.
+	omitted func(v interface{}) bool
+}
+
+func (o secOptional) Build() (string, []interface{}) {
+	return must(o.BuildE())
+}
+
+func (o secOptional) BuildE() (string, []interface{}, error) {
+	return o.build(MySQL)
+}
+
+func (o secOptional) build(d Dialect) (string, []interface{}, error) {
+	return build(d, o.b)
+}
+
+func (o secOptional) Null() bool {
+	_, null, err := optionalArgs(o)
+	// leave it in on error, so that the error is reported when it is built
+	return null && err == nil
+}
+
+// optionalArgs returns the arguments of b and whether it is null, walking SecAND, SecOR and
+// the optional builders in one pass, so that the nested ones are not built once per level.
+func optionalArgs(b Builder) ([]interface{}, bool, error) {
+	switch v := b.(type) {
+	case secRaw:
+		return v.args, false, nil
+	case secEQ:
+		return []interface{}{v.value}, false, nil
+	case secOptional:
+		if v.skip {
+			return nil, true, nil
+		}
+		args, null, err := optionalArgs(v.b)
+		if null || err != nil || v.omitted == nil {
+			return args, null, err
+		}
+		if e, ok := v.b.(emptier); ok && e.empty() {
+			return nil, true, nil
+		}
+		if len(args) == 0 {
+			// nothing to omit, like Raw("deleted_at IS NULL")
+			return args, false, nil
+		}
+		for _, a := range args {
+			if !v.omitted(a) {
+				return args, false, nil
+			}
+		}
+		return nil, true, nil
+	case SecAND:
+		return optionalSec(v)
+	case SecOR:
+		return optionalSec(v)
+	}
+	if IsNull(b) {
+		return nil, true, nil
+	}
+	_, args, err := build(MySQL, b)
+	return args, false, err
+}
+
+func optionalSec(bs []Builder) ([]interface{}, bool, error) {
+	null := true
+	args := make([]interface{}, 0)
+	for _, v := range bs {
+		a, n, err := optionalArgs(v)
+		if err != nil {
+			return nil, false, err
+		}
+		if !n {
+			null = false
+			args = append(args, a...)
+		}
+	}
+	return args, null, nil
+}
+
+// If is b when cond is true, and null otherwise.
+func If(cond bool, b Builder) Builder {
+	return secOptional{b: b, skip: !cond}
+}
+
+// OmitZero is null when every argument of b is the zero value of its type,
+// such as nil, 0, false or "", so OmitZero(EQ("status", req.Status)) filters only when Status is set.
+// A b without arguments is kept.
+func OmitZero(b Builder) Builder {
+	return secOptional{b: b, omitted: isZeroArg}
+}
+
+// OmitEmpty is null when b is given no values, like MakeIn of an empty slice,
+// or when every argument of b is nil or has a length of 0. Unlike OmitZero it keeps 0 and false.
+func OmitEmpty(b Builder) Builder {
+	return secOptional{b: b, omitted: isEmptyArg}
+}
+
+func isZeroArg(v interface{}) bool {
+	if isNil(v) {
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+func isEmptyArg(v interface{}) bool {
+	if isNil(v) {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	type request struct {
+		Name    string
+		Status  int
+		Since   time.Time
+		IDs     []interface{}
+		Deleted *bool
+		Admin   bool
+	}
+
+	where := func(r request) Builder {
+		return SecAND{
+			OmitZero(Contains("name", r.Name)),
+			OmitEmpty(EQ("status", r.Status)),
+			OmitZero(GTE("created_at", r.Since)),
+			OmitEmpty(MakeIn("id", r.IDs)),
+			OmitZero(EQ("deleted", r.Deleted)),
+			If(!r.Admin, EQ("hidden", false)),
+		}
+	}
+
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	deleted := false
+
+	var data = []struct {
+		in  request
+		out outStruct
+	}{
+		{
+			request{},
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (status = ? AND hidden = ?)",
+				vals: []interface{}{0, false},
+			},
+		},
+		{
+			request{Admin: true},
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (status = ?)",
+				vals: []interface{}{0},
+			},
+		},
+		{
+			request{Name: "a", Status: 2, Since: since, IDs: []interface{}{1, 2}, Deleted: &deleted, Admin: true},
+			outStruct{
+				cond: "SELECT * FROM tb WHERE (name LIKE ? ESCAPE '!' AND status = ? AND created_at >= ? AND id IN (?,?) AND deleted = ?)",
+				vals: []interface{}{"%a%", 2, since, 1, 2, &deleted},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Select{Table: Raw("tb"), Where: where(tc.in)}.Build()
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	q, a := Select{Table: Raw("tb"), Where: SecAND{OmitEmpty(Raw("a = ?", "")), OmitZero(Raw("b = ?", ""))}}.Build()
+	ass.Equal("SELECT * FROM tb", q)
+	ass.Equal([]interface{}{}, a)
+
+	q, a = SecAND{OmitZero(Raw("deleted_at IS NULL")), OmitEmpty(Raw("1=1")), EQ("x", 1)}.Build()
+	ass.Equal("(deleted_at IS NULL AND 1=1 AND x = ?)", q)
+	ass.Equal([]interface{}{1}, a)
+
+	calls := 0
+	counted := builderFunc(func() (string, []interface{}) {
+		calls++
+		return "c = ?", []interface{}{0}
+	})
+	q, a = SecAND{EQ("x", 1), OmitZero(SecAND{OmitZero(SecOR{OmitZero(counted)})})}.Build()
+	ass.Equal("(x = ?)", q)
+	ass.Equal([]interface{}{1}, a)
+	ass.Equal(1, calls)
+
+	ass.True(IsNull(If(true, SecAND{})))
+	ass.False(IsNull(OmitZero(Embed("$ = 0", Raw("a"), Raw("b")))))
+	ass.True(errors.Is(Validate(MySQL, SecAND{OmitZero(Embed("$ = 0", Raw("a"), Raw("b")))}), ErrPlaceholderMismatch))
+}
+
+type builderFunc func() (string, []interface{})
+
+func (f builderFunc) Build() (string, []interface{}) {
+	return f()
+}
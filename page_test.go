@@ -0,0 +1,127 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	sel := Select{Fields: []string{"id"}, Table: Raw("tb"), Where: EQ("a", 1), OrderBy: []string{"id"}}
+	page := sel
+	page.Limit = []uint{10}
+	page.Offset = 20
+	top := sel
+	top.Limit = []uint{10}
+	top.Distinct = true
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL, page,
+			outStruct{
+				cond: "SELECT id FROM tb WHERE a = ? ORDER BY id LIMIT ? OFFSET ?",
+				vals: []interface{}{1, uint(10), uint(20)},
+			},
+		},
+		{
+			PostgreSQL, page,
+			outStruct{
+				cond: "SELECT id FROM tb WHERE a = $1 ORDER BY id LIMIT $2 OFFSET $3",
+				vals: []interface{}{1, uint(10), uint(20)},
+			},
+		},
+		{
+			PostgreSQL, Select{Table: Raw("tb"), Limit: []uint{20, 10}},
+			outStruct{
+				cond: "SELECT * FROM tb LIMIT $1 OFFSET $2",
+				vals: []interface{}{uint(10), uint(20)},
+			},
+		},
+		{
+			SQLite, Select{Table: Raw("tb"), Offset: 5},
+			outStruct{
+				cond: "SELECT * FROM tb LIMIT -1 OFFSET ?",
+				vals: []interface{}{uint(5)},
+			},
+		},
+		{
+			MySQL, Select{Table: Raw("tb"), Offset: 5},
+			outStruct{
+				cond: "SELECT * FROM tb LIMIT 18446744073709551615 OFFSET ?",
+				vals: []interface{}{uint(5)},
+			},
+		},
+		{
+			SQLServer, page,
+			outStruct{
+				cond: "SELECT id FROM tb WHERE a = @p1 ORDER BY id OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY",
+				vals: []interface{}{1, uint(20), uint(10)},
+			},
+		},
+		{
+			SQLServer, top,
+			outStruct{
+				cond: "SELECT DISTINCT TOP (@p1) id FROM tb WHERE a = @p2 ORDER BY id",
+				vals: []interface{}{uint(10), 1},
+			},
+		},
+		{
+			SQLServer, Select{Table: Raw("tb"), Offset: 5},
+			outStruct{
+				cond: "SELECT * FROM tb ORDER BY (SELECT NULL) OFFSET @p1 ROWS",
+				vals: []interface{}{uint(5)},
+			},
+		},
+		{
+			Oracle, top,
+			outStruct{
+				cond: "SELECT DISTINCT id FROM tb WHERE a = :1 ORDER BY id FETCH NEXT :2 ROWS ONLY",
+				vals: []interface{}{1, uint(10)},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	zero := Select{Table: Raw("t"), Limit: []uint{0}}
+	for d, cond := range map[Dialect]string{
+		MySQL:      "SELECT * FROM t LIMIT ?",
+		PostgreSQL: "SELECT * FROM t LIMIT $1",
+		SQLite:     "SELECT * FROM t LIMIT ?",
+		Oracle:     "SELECT * FROM t FETCH NEXT :1 ROWS ONLY",
+		SQLServer:  "SELECT TOP (@p1) * FROM t",
+	} {
+		q, a := Build(d, zero)
+		ass.Equal(cond, q, d.Name())
+		ass.Equal([]interface{}{uint(0)}, a, d.Name())
+	}
+	q, a := Build(SQLServer, Select{Table: Raw("t"), Limit: []uint{0}, Offset: 5})
+	ass.Equal("SELECT * FROM t ORDER BY (SELECT NULL) OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY", q)
+	ass.Equal([]interface{}{uint(5), uint(0)}, a)
+
+	p, err := sel.Paginate(3, 10)
+	ass.NoError(err)
+	ass.Equal([]uint{10}, p.Limit)
+	ass.Equal(uint(20), p.Offset)
+	for _, v := range [][2]int{{0, 10}, {1, 0}, {-1, 10}, {1, MaxPageSize + 1}, {1 << 30, 1000}} {
+		_, err := sel.Paginate(v[0], v[1])
+		ass.True(errors.Is(err, ErrPage), "%v", v)
+	}
+
+	ass.EqualError(Validate(MySQL, Select{Table: Raw("tb"), Limit: []uint{1, 2}, Offset: 3}), "bsql: Select.Offset: invalid page")
+}
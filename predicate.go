@@ -0,0 +1,259 @@
+package bsql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+)
+
+// isNil reports whether v is nil, nil pointers and driver.Valuer giving nil count as nil.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if vr, ok := v.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return true
+		}
+		dv, err := vr.Value()
+		return err == nil && dv == nil
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+type nilAsValue struct {
+	Dialect
+}
+
+// NilAsValue returns d on which EQ and NQ bind a nil value as any other one, col = ? and col != ?,
+// rather than turning it into col IS NULL and col IS NOT NULL.
+func NilAsValue(d Dialect) Dialect {
+	return nilAsValue{Dialect: d}
+}
+
+// secEQ is col = value, or col != value if not is set.
+type secEQ struct {
+	col   string
+	not   bool
+	value interface{}
+}
+
+func (e secEQ) Build() (string, []interface{}) {
+	return must(e.BuildE())
+}
+
+func (e secEQ) BuildE() (string, []interface{}, error) {
+	return e.build(MySQL)
+}
+
+func (e secEQ) build(d Dialect) (string, []interface{}, error) {
+	if _, ok := d.(nilAsValue); !ok && isNil(e.value) {
+		if e.not {
+			return e.col + " IS NOT NULL", nil, nil
+		}
+		return e.col + " IS NULL", nil, nil
+	}
+	if e.not {
+		return e.col + " != ?", []interface{}{e.value}, nil
+	}
+	return e.col + " = ?", []interface{}{e.value}, nil
+}
+
+// Null is col IS NULL.
+func Null(col string) Builder {
+	return secRaw{query: col + " IS NULL"}
+}
+
+// NotNull is col IS NOT NULL.
+func NotNull(col string) Builder {
+	return secRaw{query: col + " IS NOT NULL"}
+}
+
+// Between is col BETWEEN from AND to.
+func Between(col string, from, to interface{}) Builder {
+	return secRaw{
+		query: col + " BETWEEN ? AND ?",
+		args:  []interface{}{from, to},
+	}
+}
+
+// NotBetween is col NOT BETWEEN from AND to.
+func NotBetween(col string, from, to interface{}) Builder {
+	return secRaw{
+		query: col + " NOT BETWEEN ? AND ?",
+		args:  []interface{}{from, to},
+	}
+}
+
+type secNot struct {
+	b Builder
+}
+
+func (n secNot) Build() (string, []interface{}) {
+	return must(n.BuildE())
+}
+
+func (n secNot) BuildE() (string, []interface{}, error) {
+	return n.build(MySQL)
+}
+
+func (n secNot) build(d Dialect) (string, []interface{}, error) {
+	q, a, err := clause(d, "Not", "", n.b)
+	if err != nil {
+		return "", nil, err
+	}
+	return "NOT (" + q + ")", a, nil
+}
+
+func (n secNot) Null() bool {
+	return IsNull(n.b)
+}
+
+// Not is NOT (b), it is null when b is, so that it is skipped by SecAND and SecOR as b would be.
+func Not(b Builder) Builder {
+	return secNot{b: b}
+}
+
+type secDistinct struct {
+	col   string
+	not   bool
+	value interface{}
+}
+
+func (s secDistinct) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s secDistinct) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s secDistinct) build(d Dialect) (string, []interface{}, error) {
+	args := []interface{}{s.value}
+	switch {
+	case is(d, "mysql"):
+		if s.not {
+			return s.col + " <=> ?", args, nil
+		}
+		return "NOT (" + s.col + " <=> ?)", args, nil
+	case is(d, "sqlite3"):
+		if s.not {
+			return s.col + " IS ?", args, nil
+		}
+		return s.col + " IS NOT ?", args, nil
+	case is(d, "oracle"):
+		if s.not {
+			return "DECODE(" + s.col + ", ?, 1, 0) = 1", args, nil
+		}
+		return "DECODE(" + s.col + ", ?, 1, 0) = 0", args, nil
+	}
+	if s.not {
+		return s.col + " IS NOT DISTINCT FROM ?", args, nil
+	}
+	return s.col + " IS DISTINCT FROM ?", args, nil
+}
+
+// IsDistinctFrom is the NULL safe col != value, using the form of each dialect.
+func IsDistinctFrom(col string, value interface{}) Builder {
+	return secDistinct{col: col, value: value}
+}
+
+// IsNotDistinctFrom is the NULL safe col = value, using the form of each dialect.
+func IsNotDistinctFrom(col string, value interface{}) Builder {
+	return secDistinct{col: col, not: true, value: value}
+}
+
+// likeEscape is the escape character of the patterns made by Contains, HasPrefix and HasSuffix,
+// backslash is avoided as MySQL also takes it as the escape of string literals.
+const likeEscape = "!"
+
+type secLike struct {
+	col     string
+	not     bool
+	fold    bool
+	pattern string
+	// escaped is the fragment matched literally between prefix and suffix
+	escaped        string
+	prefix, suffix string
+}
+
+func (l secLike) Build() (string, []interface{}) {
+	return must(l.BuildE())
+}
+
+func (l secLike) BuildE() (string, []interface{}, error) {
+	return l.build(MySQL)
+}
+
+func (l secLike) build(d Dialect) (string, []interface{}, error) {
+	pattern, escape := l.pattern, ""
+	if l.prefix != "" || l.suffix != "" {
+		pattern = l.prefix + escapeLike(d, l.escaped) + l.suffix
+		escape = " ESCAPE '" + likeEscape + "'"
+	}
+
+	col, like, ph := l.col, " LIKE ", "?"
+	if l.fold {
+		if is(d, "postgres") {
+			like = " ILIKE "
+		} else {
+			col, ph = "LOWER("+col+")", "LOWER(?)"
+		}
+	}
+	if l.not {
+		like = " NOT" + like
+	}
+
+	return col + like + ph + escape, []interface{}{pattern}, nil
+}
+
+// empty reports whether Contains, HasPrefix or HasSuffix is given an empty fragment, which matches anything.
+func (l secLike) empty() bool {
+	return l.escaped == "" && (l.prefix != "" || l.suffix != "")
+}
+
+func escapeLike(d Dialect, s string) string {
+	chars := "!%_"
+	if is(d, "sqlserver") {
+		chars += "["
+	}
+	b := strings.Builder{}
+	for _, c := range s {
+		if strings.ContainsRune(chars, c) {
+			b.WriteString(likeEscape)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Like is col LIKE pattern, pattern is used as is.
+func Like(col, pattern string) Builder {
+	return secLike{col: col, pattern: pattern}
+}
+
+// NotLike is col NOT LIKE pattern, pattern is used as is.
+func NotLike(col, pattern string) Builder {
+	return secLike{col: col, not: true, pattern: pattern}
+}
+
+// ILike is the case insensitive Like, ILIKE on PostgreSQL and LOWER(col) LIKE LOWER(pattern) elsewhere.
+func ILike(col, pattern string) Builder {
+	return secLike{col: col, fold: true, pattern: pattern}
+}
+
+// Contains matches col containing s, the wildcards in s are escaped.
+func Contains(col, s string) Builder {
+	return secLike{col: col, escaped: s, prefix: "%", suffix: "%"}
+}
+
+// HasPrefix matches col starting with s, the wildcards in s are escaped.
+func HasPrefix(col, s string) Builder {
+	return secLike{col: col, escaped: s, suffix: "%"}
+}
+
+// HasSuffix matches col ending with s, the wildcards in s are escaped.
+func HasSuffix(col, s string) Builder {
+	return secLike{col: col, escaped: s, prefix: "%"}
+}
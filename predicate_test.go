@@ -0,0 +1,59 @@
+package bsql
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredicate(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var nilInt *int
+	one := 1
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{MySQL, EQ("a", nil), outStruct{"a IS NULL", nil}},
+		{MySQL, EQ("a", nilInt), outStruct{"a IS NULL", nil}},
+		{MySQL, EQ("a", sql.NullString{}), outStruct{"a IS NULL", nil}},
+		{MySQL, EQ("a", &one), outStruct{"a = ?", []interface{}{&one}}},
+		{MySQL, NQ("a", nil), outStruct{"a IS NOT NULL", nil}},
+		{MySQL, NQ("a", sql.NullInt64{Int64: 1, Valid: true}), outStruct{"a != ?", []interface{}{sql.NullInt64{Int64: 1, Valid: true}}}},
+		{NilAsValue(MySQL), EQ("a", nil), outStruct{"a = ?", []interface{}{nil}}},
+		{NilAsValue(PostgreSQL), SecAND{NQ("a", nilInt), EQ("b", 1)}, outStruct{"(a != $1 AND b = $2)", []interface{}{nilInt, 1}}},
+		{NilAsValue(SQLServer), EQ("[a?]", nil), outStruct{"[a?] = @p1", []interface{}{nil}}},
+		{MySQL, SecAND{Null("a"), NotNull("b")}, outStruct{"(a IS NULL AND b IS NOT NULL)", []interface{}{}}},
+		{PostgreSQL, Between("a", 1, 2), outStruct{"a BETWEEN $1 AND $2", []interface{}{1, 2}}},
+		{MySQL, NotBetween("a", 1, 2), outStruct{"a NOT BETWEEN ? AND ?", []interface{}{1, 2}}},
+		{MySQL, Not(SecOR{EQ("a", 1), EQ("b", 2)}), outStruct{"NOT ((a = ? OR b = ?))", []interface{}{1, 2}}},
+		{MySQL, SecAND{EQ("a", 1), Not(SecOR{})}, outStruct{"(a = ?)", []interface{}{1}}},
+		{MySQL, IsDistinctFrom("a", 1), outStruct{"NOT (a <=> ?)", []interface{}{1}}},
+		{MySQL, IsNotDistinctFrom("a", 1), outStruct{"a <=> ?", []interface{}{1}}},
+		{PostgreSQL, IsDistinctFrom("a", 1), outStruct{"a IS DISTINCT FROM $1", []interface{}{1}}},
+		{SQLServer, IsNotDistinctFrom("a", 1), outStruct{"a IS NOT DISTINCT FROM @p1", []interface{}{1}}},
+		{SQLite, IsDistinctFrom("a", 1), outStruct{"a IS NOT ?", []interface{}{1}}},
+		{Oracle, IsDistinctFrom("a", 1), outStruct{"DECODE(a, :1, 1, 0) = 0", []interface{}{1}}},
+		{MySQL, Like("a", "x%"), outStruct{"a LIKE ?", []interface{}{"x%"}}},
+		{MySQL, NotLike("a", "x_"), outStruct{"a NOT LIKE ?", []interface{}{"x_"}}},
+		{PostgreSQL, ILike("a", "x%"), outStruct{"a ILIKE $1", []interface{}{"x%"}}},
+		{MySQL, ILike("a", "x%"), outStruct{"LOWER(a) LIKE LOWER(?)", []interface{}{"x%"}}},
+		{MySQL, Contains("a", "50%_off!"), outStruct{"a LIKE ? ESCAPE '!'", []interface{}{"%50!%!_off!!%"}}},
+		{SQLServer, HasPrefix("a", "[x]%"), outStruct{"a LIKE @p1 ESCAPE '!'", []interface{}{"![x]!%%"}}},
+		{SQLite, HasSuffix("a", "[x]_"), outStruct{"a LIKE ? ESCAPE '!'", []interface{}{"%[x]!_"}}},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+}
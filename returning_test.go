@@ -0,0 +1,99 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturning(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	values, err := MakeValues([]string{"name"}, [][]interface{}{{"a"}, {"b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			Insert{Table: Raw("tb"), Value: values, Upsert: OnConflict{}, Returning: []string{"id"}},
+			outStruct{
+				cond: "INSERT INTO tb (name) VALUES ($1),($2) ON CONFLICT DO NOTHING RETURNING id",
+				vals: []interface{}{"a", "b"},
+			},
+		},
+		{
+			SQLite,
+			Update{Table: Raw("tb"), Set: Raw("hits=hits+1"), Where: EQ("id", 1), Returning: []string{"*"}},
+			outStruct{
+				cond: "UPDATE tb SET hits=hits+1 WHERE id = ? RETURNING *",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			PostgreSQL,
+			Delete{Table: Raw("tb"), Where: EQ("id", 1), Returning: []string{"id", "name"}},
+			outStruct{
+				cond: "DELETE FROM tb WHERE id = $1 RETURNING id,name",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLServer,
+			Insert{Table: Raw("tb"), Value: values, Returning: []string{"id", "name"}},
+			outStruct{
+				cond: "INSERT INTO tb (name) OUTPUT INSERTED.id,INSERTED.name VALUES (@p1),(@p2)",
+				vals: []interface{}{"a", "b"},
+			},
+		},
+		{
+			SQLServer,
+			Insert{Table: Raw("tb"), Value: Raw("DEFAULT VALUES"), Returning: []string{"*"}},
+			outStruct{
+				cond: "INSERT INTO tb OUTPUT INSERTED.* DEFAULT VALUES",
+				vals: []interface{}{},
+			},
+		},
+		{
+			SQLServer,
+			Update{Table: Raw("tb"), Set: Raw("hits=hits+1"), Where: EQ("id", 1), Returning: []string{"hits"}},
+			outStruct{
+				cond: "UPDATE tb SET hits=hits+1 OUTPUT INSERTED.hits WHERE id = @p1",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLServer,
+			Delete{Table: Raw("tb"), Where: EQ("id", 1), Returning: []string{"id"}},
+			outStruct{
+				cond: "DELETE FROM tb OUTPUT DELETED.id WHERE id = @p1",
+				vals: []interface{}{1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	_, _, err = BuildE(SQLServer, Insert{Table: Raw("t"), Value: Raw("(a) VALUES (?)", 1), Returning: []string{"id"}})
+	ass.True(errors.Is(err, ErrUnsupported))
+
+	for _, d := range []Dialect{MySQL, Oracle} {
+		_, _, err := BuildE(d, Delete{Table: Raw("tb"), Returning: []string{"id"}})
+		ass.True(errors.Is(err, ErrUnsupported))
+		ass.EqualError(err, "bsql: Delete.Returning: not supported by dialect "+d.Name())
+	}
+}
@@ -0,0 +1,98 @@
+package bsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	OpUnion = iota
+	OpUnionAll
+	OpIntersect
+	OpExcept
+)
+
+// SetOp combines the results of Queries with the set operation Op,
+// the combined result is sorted by OrderBy and paginated by Limit and Offset, a Limit of 0 means no limit.
+//
+// A query which is a SetOp, a UnionAll, a With, a Hint, a Comment, or a Select with its own ORDER BY, LIMIT or Lock is parenthesized,
+// or made a derived table on SQLite which does not accept parenthesized queries.
+type SetOp struct {
+	Op      int8
+	Queries []Builder
+	OrderBy []string
+	Limit   uint
+	Offset  uint
+}
+
+func (s SetOp) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s SetOp) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s SetOp) build(d Dialect) (string, []interface{}, error) {
+	op := ""
+	switch s.Op {
+	case OpUnion:
+		op = " UNION "
+	case OpUnionAll:
+		op = " UNION ALL "
+	case OpIntersect:
+		op = " INTERSECT "
+	case OpExcept:
+		op = " EXCEPT "
+		if is(d, "oracle") {
+			op = " MINUS "
+		}
+	default:
+		return "", nil, &BuildError{Builder: "SetOp", Err: fmt.Errorf("%w %d", ErrSetOpType, s.Op)}
+	}
+	if len(s.Queries) == 0 {
+		return "", nil, &BuildError{Builder: "SetOp", Clause: "Queries", Err: ErrNilBuilder}
+	}
+
+	var (
+		sqls []string
+		args []interface{}
+	)
+	for i, v := range s.Queries {
+		q, a, err := clause(d, "SetOp", index(i), v)
+		if err != nil {
+			return "", nil, err
+		}
+		if compound(v) {
+			if is(d, "sqlite3") {
+				q = "SELECT * FROM (" + q + ")"
+			} else {
+				q = "(" + q + ")"
+			}
+		}
+		sqls = append(sqls, q)
+		args = append(args, a...)
+	}
+
+	orderBy := ""
+	if len(s.OrderBy) > 0 {
+		orderBy = " ORDER BY " + strings.Join(s.OrderBy, ",")
+	}
+	page, a := paginate(d, orderBy, s.Limit, s.Offset, s.Limit > 0)
+
+	return strings.Join(sqls, op) + page, append(args, a...), nil
+}
+
+// compound reports whether the query b has to be parenthesized as an operand of SetOp.
+func compound(b Builder) bool {
+	switch v := b.(type) {
+	case SetOp, UnionAll, With, secHint, secComment:
+		// the CTEs, hints or comment belong to b, not to the whole SetOp
+		return true
+	case Select:
+		return len(v.OrderBy) > 0 || len(v.Limit) > 0 || v.Offset > 0 || v.Lock != nil
+	case SelectRaw:
+		return v.OrderBy != nil || v.Limit != nil || v.Lock != nil
+	}
+	return false
+}
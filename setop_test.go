@@ -0,0 +1,93 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOp(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	t1 := Select{Fields: []string{"id"}, Table: Raw("t1"), Where: EQ("a", 1)}
+	t2 := SelectRaw{Fields: Raw("id"), Table: Raw("t2"), Where: EQ("b", 2)}
+	top := Select{Fields: []string{"id"}, Table: Raw("t3"), OrderBy: []string{"id"}, Limit: []uint{5}}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			SetOp{Op: OpUnion, Queries: []Builder{t1, t2}, OrderBy: []string{"id DESC"}, Limit: 10, Offset: 20},
+			outStruct{
+				cond: "SELECT id FROM t1 WHERE a = $1 UNION SELECT id FROM t2 WHERE b = $2 ORDER BY id DESC LIMIT $3 OFFSET $4",
+				vals: []interface{}{1, 2, uint(10), uint(20)},
+			},
+		},
+		{
+			MySQL,
+			SetOp{Op: OpExcept, Queries: []Builder{SetOp{Op: OpUnionAll, Queries: []Builder{t1, t2}}, top}},
+			outStruct{
+				cond: "(SELECT id FROM t1 WHERE a = ? UNION ALL SELECT id FROM t2 WHERE b = ?) EXCEPT (SELECT id FROM t3 ORDER BY id LIMIT ?)",
+				vals: []interface{}{1, 2, uint(5)},
+			},
+		},
+		{
+			SQLite,
+			SetOp{Op: OpIntersect, Queries: []Builder{t1, SetOp{Op: OpUnion, Queries: []Builder{t2, Raw("SELECT 3")}}}, Offset: 1},
+			outStruct{
+				cond: "SELECT id FROM t1 WHERE a = ? INTERSECT SELECT * FROM (SELECT id FROM t2 WHERE b = ? UNION SELECT 3) LIMIT -1 OFFSET ?",
+				vals: []interface{}{1, 2, uint(1)},
+			},
+		},
+		{
+			Oracle,
+			SetOp{Op: OpExcept, Queries: []Builder{t1, t2}, OrderBy: []string{"id"}, Limit: 10},
+			outStruct{
+				cond: "SELECT id FROM t1 WHERE a = :1 MINUS SELECT id FROM t2 WHERE b = :2 ORDER BY id FETCH NEXT :3 ROWS ONLY",
+				vals: []interface{}{1, 2, uint(10)},
+			},
+		},
+		{
+			SQLServer,
+			SetOp{Op: OpUnionAll, Queries: []Builder{t1, t2}, Limit: 10},
+			outStruct{
+				cond: "SELECT id FROM t1 WHERE a = @p1 UNION ALL SELECT id FROM t2 WHERE b = @p2 ORDER BY (SELECT NULL) OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY",
+				vals: []interface{}{1, 2, uint(0), uint(10)},
+			},
+		},
+		{
+			PostgreSQL,
+			SetOp{Queries: []Builder{With{CTEs: []CTE{{Name: "c", Query: Raw("SELECT 1")}}, Query: Raw("SELECT * FROM c")}, Raw("SELECT 2")}},
+			outStruct{
+				cond: "(WITH c AS (SELECT 1) SELECT * FROM c) UNION SELECT 2",
+				vals: nil,
+			},
+		},
+		{
+			MySQL,
+			SetOp{Queries: []Builder{Hint(Raw("SELECT 1"), "x"), Select{Fields: []string{"id"}, Table: Raw("t"), Lock: &Lock{Mode: ForUpdate}}}},
+			outStruct{
+				cond: "(SELECT /*+ x */ 1) UNION (SELECT id FROM t FOR UPDATE)",
+				vals: nil,
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	ass.True(errors.Is(Validate(MySQL, SetOp{Op: 8, Queries: []Builder{t1}}), ErrSetOpType))
+	ass.EqualError(Validate(MySQL, SetOp{}), "bsql: SetOp.Queries: builder is nil")
+	ass.EqualError(Validate(MySQL, SetOp{Queries: []Builder{t1, Select{}}}), "bsql: SetOp[1]: Select.Table: builder is nil")
+}
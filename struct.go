@@ -0,0 +1,194 @@
+package bsql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/forsaken628/bsql/internal/dbtag"
+)
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// structField is a column of a struct type, read from the `db` tag:
+//
+//	`db:"name"`               the column name, the lower cased field name without a tag
+//	`db:"name,omitempty"`     left out when the field is the zero value
+//	`db:"id,autoincrement"`   left out of INSERT when zero and never SET
+//	`db:"created,readonly"`   never written
+//	`db:"-"`                  not a column
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	autoIncr  bool
+	readonly  bool
+}
+
+var structFields sync.Map
+
+func structFieldsOf(t reflect.Type) []structField {
+	if fs, ok := structFields.Load(t); ok {
+		return fs.([]structField)
+	}
+
+	var fs []structField
+	for _, f := range dbtag.Fields(t, isValue) {
+		sf := structField{name: f.Name, index: f.Index}
+		for _, o := range f.Opts {
+			switch o {
+			case "omitempty":
+				sf.omitEmpty = true
+			case "autoincrement":
+				sf.autoIncr = true
+			case "readonly":
+				sf.readonly = true
+			}
+		}
+		fs = append(fs, sf)
+	}
+
+	structFields.Store(t, fs)
+	return fs
+}
+
+// isValue reports whether a struct type is a single column value rather than a group of columns.
+func isValue(t reflect.Type) bool {
+	return t == timeType || t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType)
+}
+
+// fieldOf returns the field at index, the zero Value if it is behind a nil embedded pointer.
+func fieldOf(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func isZero(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func structValue(v interface{}) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return rv, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+// MakeValuesStruct is MakeValues of the columns of v, which is a struct, a slice of structs, or pointers to them.
+// With a slice, an omitempty or autoincrement column is left out only if it is empty in every row,
+// and an autoincrement column which is zero in some rows but not in the others is an error.
+func MakeValuesStruct(v interface{}) (Builder, error) {
+	var rows []reflect.Value
+	if rv := reflect.Indirect(reflect.ValueOf(v)); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			e, ok := structValue(rv.Index(i).Interface())
+			if !ok {
+				return nil, errors.New("insert values not struct")
+			}
+			if len(rows) > 0 && e.Type() != rows[0].Type() {
+				return nil, errors.New("insert values not match")
+			}
+			rows = append(rows, e)
+		}
+	} else {
+		e, ok := structValue(v)
+		if !ok {
+			return nil, errors.New("insert values not struct")
+		}
+		rows = append(rows, e)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("insert null values")
+	}
+
+	t := rows[0].Type()
+	var (
+		cols   []string
+		fields []structField
+	)
+	for _, f := range structFieldsOf(t) {
+		if f.readonly {
+			continue
+		}
+		if f.omitEmpty || f.autoIncr {
+			zeros := 0
+			for _, r := range rows {
+				if isZero(fieldOf(r, f.index)) {
+					zeros++
+				}
+			}
+			if zeros == len(rows) {
+				continue
+			}
+			if f.autoIncr && zeros > 0 {
+				// the zero rows would insert an explicit 0 instead of generating one
+				return nil, errors.New("insert values mix zero and set autoincrement " + f.name)
+			}
+		}
+		cols = append(cols, f.name)
+		fields = append(fields, f)
+	}
+
+	values := make([][]interface{}, len(rows))
+	for i, r := range rows {
+		values[i] = make([]interface{}, len(fields))
+		for k, f := range fields {
+			values[i][k] = valueOf(fieldOf(r, f.index))
+		}
+	}
+
+	return MakeValues(cols, values)
+}
+
+// MakeSetStruct is MakeSet of the columns of v, which is a struct or a pointer to it, in the order of the fields.
+func MakeSetStruct(v interface{}) (Builder, error) {
+	rv, ok := structValue(v)
+	if !ok {
+		return nil, errors.New("update set not struct")
+	}
+
+	set := secRaw{}
+	ss := make([]string, 0)
+	for _, f := range structFieldsOf(rv.Type()) {
+		if f.readonly || f.autoIncr {
+			continue
+		}
+		fv := fieldOf(rv, f.index)
+		if f.omitEmpty && isZero(fv) {
+			continue
+		}
+		ss = append(ss, f.name+"=?")
+		set.args = append(set.args, valueOf(fv))
+	}
+	if len(ss) == 0 {
+		return nil, errors.New("update null set")
+	}
+	set.query = strings.Join(ss, ",")
+
+	return set, nil
+}
@@ -0,0 +1,159 @@
+package bsql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Model struct {
+	ID      int64     `db:"id,autoincrement"`
+	Created time.Time `db:"created_at,readonly"`
+}
+
+type Extra struct {
+	Note string `db:"note,omitempty"`
+}
+
+type item struct {
+	Model
+	*Extra
+	Name   string         `db:"name"`
+	Price  int            `db:"price,omitempty"`
+	Coupon sql.NullString `db:"coupon"`
+	Count  int
+	Skip   string `db:"-"`
+	hidden string
+}
+
+func TestMakeValuesStruct(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		in  interface{}
+		out outStruct
+	}{
+		{
+			item{Name: "a", Count: 1, Skip: "x", hidden: "y"},
+			outStruct{
+				cond: "INSERT INTO tb (name,coupon,count) VALUES (?,?,?)",
+				vals: []interface{}{"a", sql.NullString{}, 1},
+			},
+		},
+		{
+			&item{Model: Model{ID: 9, Created: time.Now()}, Extra: &Extra{Note: "n"}, Name: "a", Price: 3},
+			outStruct{
+				cond: "INSERT INTO tb (id,note,name,price,coupon,count) VALUES (?,?,?,?,?,?)",
+				vals: []interface{}{int64(9), "n", "a", 3, sql.NullString{}, 0},
+			},
+		},
+		{
+			[]*item{{Name: "a"}, {Name: "b", Price: 2, Extra: &Extra{}}},
+			outStruct{
+				cond: "INSERT INTO tb (name,price,coupon,count) VALUES (?,?,?,?),(?,?,?,?)",
+				vals: []interface{}{"a", 0, sql.NullString{}, 0, "b", 2, sql.NullString{}, 0},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		v, err := MakeValuesStruct(tc.in)
+		if ass.NoError(err) {
+			q, a := Insert{Table: Raw("tb"), Value: v}.Build()
+			ass.Equal(tc.out.cond, q)
+			ass.Equal(tc.out.vals, a)
+		}
+	}
+
+	for _, v := range []interface{}{nil, 1, []item{}, []interface{}{item{}, Model{}}, (*item)(nil)} {
+		_, err := MakeValuesStruct(v)
+		ass.Error(err)
+	}
+
+	_, err := MakeValuesStruct([]item{{Name: "a"}, {Model: Model{ID: 5}, Name: "b"}})
+	ass.EqualError(err, "insert values mix zero and set autoincrement id")
+}
+
+func TestMakeSetStruct(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		in  interface{}
+		out outStruct
+	}{
+		{
+			item{Model: Model{ID: 1}, Name: "a", Count: 2},
+			outStruct{
+				cond: "UPDATE tb SET name=?,coupon=?,count=? WHERE id = ?",
+				vals: []interface{}{"a", sql.NullString{}, 2, 1},
+			},
+		},
+		{
+			&item{Extra: &Extra{Note: "n"}, Price: 5},
+			outStruct{
+				cond: "UPDATE tb SET note=?,name=?,price=?,coupon=?,count=? WHERE id = ?",
+				vals: []interface{}{"n", "", 5, sql.NullString{}, 0, 1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		s, err := MakeSetStruct(tc.in)
+		if ass.NoError(err) {
+			q, a := Update{Table: Raw("tb"), Set: s, Where: EQ("id", 1)}.Build()
+			ass.Equal(tc.out.cond, q)
+			ass.Equal(tc.out.vals, a)
+		}
+	}
+
+	_, err := MakeSetStruct([]item{})
+	ass.Error(err)
+	_, err = MakeSetStruct(Model{})
+	ass.Error(err)
+}
+
+type Node struct {
+	*Node
+	ID int `db:"id"`
+}
+
+type DupA struct {
+	Name string `db:"name"`
+	X    int    `db:"x"`
+}
+
+type DupB struct {
+	Name string `db:"name"`
+}
+
+func TestStructFields(t *testing.T) {
+	ass := assert.New(t)
+
+	s, err := MakeSetStruct(Node{Node: &Node{ID: 2}, ID: 1})
+	if ass.NoError(err) {
+		q, a := s.Build()
+		ass.Equal("id=?", q)
+		ass.Equal([]interface{}{1}, a)
+	}
+
+	v, err := MakeValuesStruct(struct {
+		DupA
+		DupB
+		ID int `db:"id"`
+	}{DupA{"a", 1}, DupB{"b"}, 2})
+	if ass.NoError(err) {
+		q, a := v.Build()
+		ass.Equal("(x,id) VALUES (?,?)", q)
+		ass.Equal([]interface{}{1, 2}, a)
+	}
+}
@@ -0,0 +1,90 @@
+package bsql
+
+import (
+	"fmt"
+)
+
+func isCmpOp(op string) bool {
+	switch op {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// secSub is a predicate with a subquery on its right hand side.
+type secSub struct {
+	name  string
+	col   string
+	op    string
+	quant string
+	b     Builder
+}
+
+func (s secSub) Build() (string, []interface{}) {
+	return must(s.BuildE())
+}
+
+func (s secSub) BuildE() (string, []interface{}, error) {
+	return s.build(MySQL)
+}
+
+func (s secSub) build(d Dialect) (string, []interface{}, error) {
+	// a quantified subquery, ANY or ALL, is only compared with a comparison operator
+	if s.col != "" && !isCmpOp(s.op) && (s.quant != "" || s.op != "IN" && s.op != "NOT IN") {
+		return "", nil, &BuildError{Builder: s.name, Err: fmt.Errorf("%w %q", ErrOperator, s.op)}
+	}
+	if s.quant != "" && is(d, "sqlite3") {
+		return "", nil, unsupported(s.name, "", d)
+	}
+
+	q, a, err := clause(d, s.name, "", s.b)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pre := s.op + " "
+	if s.col != "" {
+		pre = s.col + " " + pre
+	}
+	if s.quant != "" {
+		pre += s.quant + " "
+	}
+
+	return pre + "(" + q + ")", a, nil
+}
+
+// Exists is EXISTS (b).
+func Exists(b Builder) Builder {
+	return secSub{name: "Exists", op: "EXISTS", b: b}
+}
+
+// NotExists is NOT EXISTS (b).
+func NotExists(b Builder) Builder {
+	return secSub{name: "NotExists", op: "NOT EXISTS", b: b}
+}
+
+// InSub is col IN (b).
+func InSub(col string, b Builder) Builder {
+	return secSub{name: "InSub", col: col, op: "IN", b: b}
+}
+
+// NotInSub is col NOT IN (b).
+func NotInSub(col string, b Builder) Builder {
+	return secSub{name: "NotInSub", col: col, op: "NOT IN", b: b}
+}
+
+// CmpSub compares col with the scalar subquery b, col op (b).
+func CmpSub(col, op string, b Builder) Builder {
+	return secSub{name: "CmpSub", col: col, op: op, b: b}
+}
+
+// AnySub is col op ANY (b), op is a comparison operator, not supported by SQLite.
+func AnySub(col, op string, b Builder) Builder {
+	return secSub{name: "AnySub", col: col, op: op, quant: "ANY", b: b}
+}
+
+// AllSub is col op ALL (b), op is a comparison operator, not supported by SQLite.
+func AllSub(col, op string, b Builder) Builder {
+	return secSub{name: "AllSub", col: col, op: op, quant: "ALL", b: b}
+}
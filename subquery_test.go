@@ -0,0 +1,69 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubquery(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	orders := Select{Fields: []string{"user_id"}, Table: Raw("orders"), Where: GT("amount", 100)}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			Select{
+				Table: Raw("users u"),
+				Where: SecAND{
+					EQ("u.status", 1),
+					Exists(Select{Fields: []string{"1"}, Table: Raw("orders o"), Where: SecAND{Raw("o.user_id = u.id"), GT("o.amount", 10)}}),
+					InSub("u.id", orders),
+					NotInSub("u.id", Select{Fields: []string{"user_id"}, Table: Raw("bans"), Where: EQ("active", true)}),
+				},
+			},
+			outStruct{
+				cond: "SELECT * FROM users u WHERE (u.status = $1 AND EXISTS (SELECT 1 FROM orders o WHERE (o.user_id = u.id AND o.amount > $2)) AND u.id IN (SELECT user_id FROM orders WHERE amount > $3) AND u.id NOT IN (SELECT user_id FROM bans WHERE active = $4))",
+				vals: []interface{}{1, 10, 100, true},
+			},
+		},
+		{
+			MySQL,
+			SecOR{
+				NotExists(orders),
+				AnySub("score", ">", Select{Fields: []string{"score"}, Table: Raw("t"), Where: EQ("k", 1)}),
+				AllSub("score", "<=", Raw("SELECT score FROM t WHERE k = ?", 2)),
+				CmpSub("price", "=", Select{Fields: []string{"max(price)"}, Table: Raw("t"), Where: EQ("k", 3)}),
+			},
+			outStruct{
+				cond: "(NOT EXISTS (SELECT user_id FROM orders WHERE amount > ?) OR score > ANY (SELECT score FROM t WHERE k = ?) OR score <= ALL (SELECT score FROM t WHERE k = ?) OR price = (SELECT max(price) FROM t WHERE k = ?))",
+				vals: []interface{}{100, 1, 2, 3},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	_, _, err := BuildE(SQLite, AnySub("a", "=", orders))
+	ass.True(errors.Is(err, ErrUnsupported))
+	ass.True(errors.Is(Validate(MySQL, CmpSub("a", "= 1 OR 1 =", orders)), ErrOperator))
+	ass.True(errors.Is(Validate(MySQL, AnySub("a", "IN", orders)), ErrOperator))
+	ass.True(errors.Is(Validate(MySQL, AllSub("a", "NOT IN", orders)), ErrOperator))
+	ass.NoError(Validate(MySQL, CmpSub("a", "IN", orders)))
+	ass.True(errors.Is(Validate(MySQL, Exists(Select{})), ErrNilBuilder))
+	ass.EqualError(Validate(MySQL, InSub("a", nil)), "bsql: InSub: builder is nil")
+}
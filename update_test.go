@@ -0,0 +1,102 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateJoin(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL,
+			Update{
+				Table: From(Raw("orders o")).Join(Raw("users u"), Raw("u.id = o.user_id")),
+				Set:   Raw("o.status = ?", 2),
+				Where: EQ("u.banned", true),
+			},
+			outStruct{
+				cond: "UPDATE orders o JOIN users u ON u.id = o.user_id SET o.status = ? WHERE u.banned = ?",
+				vals: []interface{}{2, true},
+			},
+		},
+		{
+			MySQL,
+			Update{Table: Raw("jobs"), Set: Raw("status = ?", 1), Where: EQ("status", 0), OrderBy: []string{"id"}, Limit: 1000},
+			outStruct{
+				cond: "UPDATE jobs SET status = ? WHERE status = ? ORDER BY id LIMIT ?",
+				vals: []interface{}{1, 0, uint(1000)},
+			},
+		},
+		{
+			PostgreSQL,
+			Update{
+				Table:     Raw("orders o"),
+				Set:       Raw("status = ?", 2),
+				From:      Raw("users u"),
+				Where:     SecAND{Raw("u.id = o.user_id"), EQ("u.banned", true)},
+				Returning: []string{"o.id"},
+			},
+			outStruct{
+				cond: "UPDATE orders o SET status = $1 FROM users u WHERE (u.id = o.user_id AND u.banned = $2) RETURNING o.id",
+				vals: []interface{}{2, true},
+			},
+		},
+		{
+			SQLServer,
+			Update{
+				Table:     Raw("o"),
+				Set:       Raw("status = ?", 2),
+				From:      From(Raw("orders o")).Join(Raw("users u"), Raw("u.id = o.user_id")),
+				Where:     EQ("u.banned", true),
+				Limit:     100,
+				Returning: []string{"id"},
+			},
+			outStruct{
+				cond: "UPDATE TOP (@p1) o SET status = @p2 OUTPUT INSERTED.id FROM orders o JOIN users u ON u.id = o.user_id WHERE u.banned = @p3",
+				vals: []interface{}{uint(100), 2, true},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	join := MakeJoin(InnerJoin, Raw("a"), Raw("b"), Raw("a.id = b.id"))
+	var errs = []struct {
+		d  Dialect
+		in Update
+	}{
+		{PostgreSQL, Update{Table: join, Set: Raw("x = 1")}},
+		{MySQL, Update{Table: Raw("a"), Set: Raw("x = 1"), From: Raw("b")}},
+		{Oracle, Update{Table: Raw("a"), Set: Raw("x = 1"), From: Raw("b")}},
+		{PostgreSQL, Update{Table: Raw("a"), Set: Raw("x = 1"), OrderBy: []string{"id"}}},
+		{SQLite, Update{Table: Raw("a"), Set: Raw("x = 1"), Limit: 1}},
+	}
+	for _, tc := range errs {
+		_, _, err := BuildE(tc.d, tc.in)
+		ass.True(errors.Is(err, ErrUnsupported), "%v", err)
+	}
+	_, _, err := BuildE(PostgreSQL, Update{Table: join, Set: Raw("x = 1")})
+	ass.EqualError(err, "bsql: Update.Table: not supported by dialect postgres")
+
+	_, _, err = BuildE(MySQL, Update{Table: join, Set: Raw("x = 1"), OrderBy: []string{"id"}})
+	ass.True(errors.Is(err, ErrMultiTable))
+	ass.EqualError(err, "bsql: Update.OrderBy: invalid multiple-table statement, joined tables can't be ordered")
+	_, _, err = BuildE(MySQL, Update{Table: join, Set: Raw("x = 1"), Limit: 1})
+	ass.EqualError(err, "bsql: Update.Limit: invalid multiple-table statement, joined tables can't be limited")
+}
@@ -0,0 +1,141 @@
+package bsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OnDuplicateKeyUpdate is the MySQL Upsert of Insert.
+type OnDuplicateKeyUpdate struct {
+	// Alias names the inserted row, so that Set can refer to its columns as alias.col, requires MySQL 8.0.19.
+	Alias string
+	Set   Builder
+}
+
+func (o OnDuplicateKeyUpdate) Build() (string, []interface{}) {
+	return must(o.BuildE())
+}
+
+func (o OnDuplicateKeyUpdate) BuildE() (string, []interface{}, error) {
+	return o.build(MySQL)
+}
+
+func (o OnDuplicateKeyUpdate) build(d Dialect) (string, []interface{}, error) {
+	if !is(d, "mysql") {
+		return "", nil, unsupported("OnDuplicateKeyUpdate", "", d)
+	}
+
+	set, args, err := clause(d, "OnDuplicateKeyUpdate", "Set", o.Set)
+	if err != nil {
+		return "", nil, err
+	}
+
+	alias := ""
+	if o.Alias != "" {
+		alias = "AS " + o.Alias + " "
+	}
+
+	return alias + "ON DUPLICATE KEY UPDATE " + set, args, nil
+}
+
+// OnConflict is the PostgreSQL and SQLite Upsert of Insert, it does nothing on conflict if Set is nil.
+// Build and BuildE default to MySQL, which doesn't support it, so an Insert with it is built by
+// bsql.Build(PostgreSQL, ...) or bsql.Build(SQLite, ...).
+type OnConflict struct {
+	// Target lists the columns of the unique index, TargetWhere is the predicate of a partial one.
+	Target      []string
+	TargetWhere Builder
+	// Constraint names the constraint instead of Target, PostgreSQL only.
+	Constraint string
+	Set        Builder
+	Where      Builder
+}
+
+func (o OnConflict) Build() (string, []interface{}) {
+	return must(o.BuildE())
+}
+
+func (o OnConflict) BuildE() (string, []interface{}, error) {
+	return o.build(MySQL)
+}
+
+func (o OnConflict) build(d Dialect) (string, []interface{}, error) {
+	if !is(d, "postgres", "sqlite3") || o.Constraint != "" && !is(d, "postgres") {
+		return "", nil, unsupported("OnConflict", "", d)
+	}
+
+	args := make([]interface{}, 0)
+	b := strings.Builder{}
+	b.WriteString("ON CONFLICT")
+
+	if o.Constraint != "" {
+		b.WriteString(" ON CONSTRAINT " + o.Constraint)
+	} else if len(o.Target) > 0 {
+		b.WriteString(" (" + strings.Join(o.Target, ",") + ")")
+		if !IsNull(o.TargetWhere) {
+			q, a, err := clause(d, "OnConflict", "TargetWhere", o.TargetWhere)
+			if err != nil {
+				return "", nil, err
+			}
+			b.WriteString(" WHERE " + q)
+			args = append(args, a...)
+		}
+	}
+
+	if o.Set == nil {
+		b.WriteString(" DO NOTHING")
+		return b.String(), args, nil
+	}
+
+	if len(o.Target) == 0 && o.Constraint == "" {
+		return "", nil, &BuildError{Builder: "OnConflict", Clause: "Target", Err: fmt.Errorf("%w, DO UPDATE needs Target or Constraint", ErrConflictTarget)}
+	}
+
+	q, a, err := clause(d, "OnConflict", "Set", o.Set)
+	if err != nil {
+		return "", nil, err
+	}
+	b.WriteString(" DO UPDATE SET " + q)
+	args = append(args, a...)
+
+	if !IsNull(o.Where) {
+		q, a, err := clause(d, "OnConflict", "Where", o.Where)
+		if err != nil {
+			return "", nil, err
+		}
+		b.WriteString(" WHERE " + q)
+		args = append(args, a...)
+	}
+
+	return b.String(), args, nil
+}
+
+type secExcluded struct {
+	cols []string
+}
+
+func (e secExcluded) Build() (string, []interface{}) {
+	return must(e.BuildE())
+}
+
+func (e secExcluded) BuildE() (string, []interface{}, error) {
+	return e.build(MySQL)
+}
+
+func (e secExcluded) build(d Dialect) (string, []interface{}, error) {
+	ss := make([]string, len(e.cols))
+	for i, v := range e.cols {
+		if is(d, "mysql") {
+			ss[i] = v + "=VALUES(" + v + ")"
+		} else {
+			ss[i] = v + "=EXCLUDED." + v
+		}
+	}
+	return strings.Join(ss, ","), nil, nil
+}
+
+// MakeSetExcluded sets each of cols to the value it was to be inserted with,
+// col=VALUES(col) on MySQL and col=EXCLUDED.col otherwise.
+func MakeSetExcluded(cols ...string) Builder {
+	return secExcluded{cols: cols}
+}
@@ -0,0 +1,104 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsert(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	values, err := MakeValues([]string{"id", "name", "hits"}, [][]interface{}{{1, "a", 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			MySQL,
+			OnDuplicateKeyUpdate{Set: MakeSetExcluded("name", "hits")},
+			outStruct{
+				cond: "INSERT INTO tb (id,name,hits) VALUES (?,?,?) ON DUPLICATE KEY UPDATE name=VALUES(name),hits=VALUES(hits)",
+				vals: []interface{}{1, "a", 1},
+			},
+		},
+		{
+			MySQL,
+			OnDuplicateKeyUpdate{Alias: "new", Set: SecComma{Raw("name=new.name"), Raw("hits=hits+?", 1)}},
+			outStruct{
+				cond: "INSERT INTO tb (id,name,hits) VALUES (?,?,?) AS new ON DUPLICATE KEY UPDATE name=new.name,hits=hits+?",
+				vals: []interface{}{1, "a", 1, 1},
+			},
+		},
+		{
+			PostgreSQL,
+			OnConflict{
+				Target: []string{"id"},
+				Set:    SecComma{MakeSetExcluded("name"), Raw("hits=tb.hits+?", 1)},
+				Where:  Raw("tb.name <> ?", "locked"),
+			},
+			outStruct{
+				cond: "INSERT INTO tb (id,name,hits) VALUES ($1,$2,$3) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name,hits=tb.hits+$4 WHERE tb.name <> $5",
+				vals: []interface{}{1, "a", 1, 1, "locked"},
+			},
+		},
+		{
+			PostgreSQL,
+			OnConflict{Constraint: "tb_pkey", Set: MakeSetSort(map[string]interface{}{"hits": 0})},
+			outStruct{
+				cond: "INSERT INTO tb (id,name,hits) VALUES ($1,$2,$3) ON CONFLICT ON CONSTRAINT tb_pkey DO UPDATE SET hits=$4",
+				vals: []interface{}{1, "a", 1, 0},
+			},
+		},
+		{
+			SQLite,
+			OnConflict{Target: []string{"name"}, TargetWhere: Raw("hits > ?", 0)},
+			outStruct{
+				cond: "INSERT INTO tb (id,name,hits) VALUES (?,?,?) ON CONFLICT (name) WHERE hits > ? DO NOTHING",
+				vals: []interface{}{1, "a", 1, 0},
+			},
+		},
+		{
+			SQLite,
+			OnConflict{},
+			outStruct{
+				cond: "INSERT INTO tb (id,name,hits) VALUES (?,?,?) ON CONFLICT DO NOTHING",
+				vals: []interface{}{1, "a", 1},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, Insert{Table: Raw("tb"), Value: values, Upsert: tc.in})
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	for _, tc := range []struct {
+		d  Dialect
+		in Builder
+	}{
+		{PostgreSQL, OnDuplicateKeyUpdate{Set: Raw("a=1")}},
+		{MySQL, OnConflict{}},
+		{SQLite, OnConflict{Constraint: "tb_pkey"}},
+	} {
+		_, _, err := BuildE(tc.d, Insert{Table: Raw("tb"), Value: values, Upsert: tc.in})
+		ass.True(errors.Is(err, ErrUnsupported))
+	}
+	_, _, err = BuildE(PostgreSQL, Insert{Table: Raw("tb"), Value: values, Upsert: OnConflict{Set: Raw("a=1")}})
+	ass.True(errors.Is(err, ErrConflictTarget))
+	ass.EqualError(err, "bsql: Insert.Upsert: OnConflict.Target: invalid conflict target, DO UPDATE needs Target or Constraint")
+
+	_, _, err = OnConflict{}.BuildE()
+	ass.True(errors.Is(err, ErrUnsupported))
+}
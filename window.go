@@ -0,0 +1,153 @@
+package bsql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The frame bounds of Rows and Range.
+const (
+	UnboundedPreceding = "UNBOUNDED PRECEDING"
+	CurrentRow         = "CURRENT ROW"
+	UnboundedFollowing = "UNBOUNDED FOLLOWING"
+)
+
+func Preceding(n int) string {
+	return strconv.Itoa(n) + " PRECEDING"
+}
+
+func Following(n int) string {
+	return strconv.Itoa(n) + " FOLLOWING"
+}
+
+func frame(mode, start, end string) Builder {
+	if end == "" {
+		return Raw(mode + " " + start)
+	}
+	return Raw(mode + " BETWEEN " + start + " AND " + end)
+}
+
+// Rows is the ROWS frame of a Window from start to end, or from start to the current row if end is empty.
+func Rows(start, end string) Builder {
+	return frame("ROWS", start, end)
+}
+
+// Range is the RANGE frame of a Window from start to end, or from start to the current row if end is empty.
+func Range(start, end string) Builder {
+	return frame("RANGE", start, end)
+}
+
+// Window is the window specification of Over.
+type Window struct {
+	// Base refers to a window named by the WINDOW clause of the query.
+	Base        string
+	PartitionBy []string
+	OrderBy     []string
+	// Frame is built with Rows, Range or Raw.
+	Frame Builder
+}
+
+func (w Window) Build() (string, []interface{}) {
+	return must(w.BuildE())
+}
+
+func (w Window) BuildE() (string, []interface{}, error) {
+	return w.build(MySQL)
+}
+
+func (w Window) build(d Dialect) (string, []interface{}, error) {
+	if w.Base != "" && len(w.PartitionBy) == 0 && len(w.OrderBy) == 0 && w.Frame == nil {
+		return w.Base, nil, nil
+	}
+	q, a, err := w.spec(d)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + q + ")", a, nil
+}
+
+func (w Window) spec(d Dialect) (string, []interface{}, error) {
+	var (
+		ss   []string
+		args []interface{}
+	)
+	if w.Base != "" {
+		ss = append(ss, w.Base)
+	}
+	if len(w.PartitionBy) > 0 {
+		ss = append(ss, "PARTITION BY "+strings.Join(w.PartitionBy, ","))
+	}
+	if len(w.OrderBy) > 0 {
+		ss = append(ss, "ORDER BY "+strings.Join(w.OrderBy, ","))
+	}
+	if w.Frame != nil {
+		q, a, err := clause(d, "Window", "Frame", w.Frame)
+		if err != nil {
+			return "", nil, err
+		}
+		ss = append(ss, q)
+		args = append(args, a...)
+	}
+	return strings.Join(ss, " "), args, nil
+}
+
+// NamedWindow is a window of the WINDOW clause of Select, which Window.Base and OverName refer to.
+type NamedWindow struct {
+	Name   string
+	Window Window
+}
+
+func (n NamedWindow) Build() (string, []interface{}) {
+	return must(n.BuildE())
+}
+
+func (n NamedWindow) BuildE() (string, []interface{}, error) {
+	return n.build(MySQL)
+}
+
+func (n NamedWindow) build(d Dialect) (string, []interface{}, error) {
+	if n.Name == "" {
+		return "", nil, &BuildError{Builder: "NamedWindow", Clause: "Name", Err: ErrInvalidIdent}
+	}
+	q, a, err := n.Window.spec(d)
+	if err != nil {
+		return "", nil, err
+	}
+	return n.Name + " AS (" + q + ")", a, nil
+}
+
+type secOver struct {
+	fn Builder
+	w  Builder
+}
+
+func (o secOver) Build() (string, []interface{}) {
+	return must(o.BuildE())
+}
+
+func (o secOver) BuildE() (string, []interface{}, error) {
+	return o.build(MySQL)
+}
+
+func (o secOver) build(d Dialect) (string, []interface{}, error) {
+	q, args, err := clause(d, "Over", "Func", o.fn)
+	if err != nil {
+		return "", nil, err
+	}
+	w, a, err := clause(d, "Over", "Window", o.w)
+	if err != nil {
+		return "", nil, err
+	}
+	return q + " OVER " + w, append(args, a...), nil
+}
+
+// Over applies the function call fn, usually made by Func, over the window w,
+// Over(Func("ROW_NUMBER"), Window{PartitionBy: []string{"dept"}, OrderBy: []string{"salary DESC"}}).
+func Over(fn Builder, w Window) Builder {
+	return secOver{fn: fn, w: w}
+}
+
+// OverName applies fn over the window named name by the WINDOW clause.
+func OverName(fn Builder, name string) Builder {
+	return secOver{fn: fn, w: Raw(name)}
+}
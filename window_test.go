@@ -0,0 +1,88 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindow(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			SelectRaw{
+				Fields: SecComma{
+					Raw("id"),
+					MakeAlias(Over(Func("ROW_NUMBER"), Window{PartitionBy: []string{"dept"}, OrderBy: []string{"salary DESC"}}), "rn"),
+					MakeAlias(Over(Func("SUM", Raw("salary")), Window{
+						OrderBy: []string{"id"},
+						Frame:   Rows(Preceding(2), CurrentRow),
+					}), "s"),
+				},
+				Table: Raw("emp"),
+				Where: GT("salary", 100),
+			},
+			outStruct{
+				cond: "SELECT id,(ROW_NUMBER() OVER (PARTITION BY dept ORDER BY salary DESC)) AS rn,(SUM(salary) OVER (ORDER BY id ROWS BETWEEN 2 PRECEDING AND CURRENT ROW)) AS s FROM emp WHERE salary > $1",
+				vals: []interface{}{100},
+			},
+		},
+		{
+			MySQL,
+			Select{
+				Fields: []string{"id"},
+				Table:  Raw("emp"),
+				Window: []NamedWindow{
+					{Name: "w", Window: Window{PartitionBy: []string{"dept"}, OrderBy: []string{"id"}}},
+					{Name: "w2", Window: Window{Base: "w", Frame: Range(UnboundedPreceding, "")}},
+				},
+				OrderBy: []string{"id"},
+			},
+			outStruct{
+				cond: "SELECT id FROM emp WINDOW w AS (PARTITION BY dept ORDER BY id),w2 AS (w RANGE UNBOUNDED PRECEDING) ORDER BY id",
+				vals: []interface{}{},
+			},
+		},
+		{
+			MySQL,
+			SecComma{
+				Over(Func("LAG", Raw("price"), Raw("?", 1)), Window{Base: "w"}),
+				OverName(Func("RANK"), "w"),
+				Over(Func("AVG", Raw("price")), Window{Frame: Raw("ROWS BETWEEN ? PRECEDING AND ? FOLLOWING", 3, 3)}),
+				Over(Func("COUNT", Raw("*")), Window{Frame: Rows(UnboundedPreceding, Following(1))}),
+			},
+			outStruct{
+				cond: "LAG(price,?) OVER w,RANK() OVER w,AVG(price) OVER (ROWS BETWEEN ? PRECEDING AND ? FOLLOWING),COUNT(*) OVER (ROWS BETWEEN UNBOUNDED PRECEDING AND 1 FOLLOWING)",
+				vals: []interface{}{1, 3, 3},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	ass.EqualError(Validate(MySQL, Over(nil, Window{})), "bsql: Over.Func: builder is nil")
+	ass.True(errors.Is(Validate(MySQL, Select{Table: Raw("t"), Window: []NamedWindow{{Window: Window{}}}}), ErrInvalidIdent))
+
+	named := Select{Fields: []string{"id"}, Table: Raw("t"), Window: []NamedWindow{{Name: "w", Window: Window{OrderBy: []string{"id"}}}}}
+	for _, d := range []Dialect{SQLServer, Oracle} {
+		_, _, err := BuildE(d, named)
+		ass.True(errors.Is(err, ErrUnsupported))
+		_, _, err = BuildE(d, SelectRaw{Fields: Raw("id"), Table: Raw("t"), Window: Raw("w AS (ORDER BY id)")})
+		ass.True(errors.Is(err, ErrUnsupported))
+	}
+}
@@ -0,0 +1,74 @@
+package bsql
+
+import (
+	"strings"
+)
+
+// CTE is a named query of With.
+type CTE struct {
+	Name    string
+	Columns []string
+	Query   Builder
+}
+
+// With prefixes Query, a Select, UnionAll, Update, Insert or Delete, with the common table expressions it refers to.
+// The arguments of the CTEs come first, in the order they are listed.
+type With struct {
+	// Recursive lets the CTEs refer to themselves, the keyword is left out on SQL Server and Oracle which do not use it.
+	Recursive bool
+	CTEs      []CTE
+	Query     Builder
+}
+
+func (w With) Build() (string, []interface{}) {
+	return must(w.BuildE())
+}
+
+func (w With) BuildE() (string, []interface{}, error) {
+	return w.build(MySQL)
+}
+
+func (w With) build(d Dialect) (string, []interface{}, error) {
+	if len(w.CTEs) == 0 {
+		return "", nil, &BuildError{Builder: "With", Clause: "CTEs", Err: ErrNilBuilder}
+	}
+	if _, ok := w.Query.(Insert); ok && is(d, "mysql") {
+		// MySQL takes the WITH after INSERT, put it in the Value instead
+		return "", nil, unsupported("With", "Query", d)
+	}
+
+	args := make([]interface{}, 0)
+	b := strings.Builder{}
+	b.WriteString("WITH ")
+	if w.Recursive && !is(d, "sqlserver", "oracle") {
+		b.WriteString("RECURSIVE ")
+	}
+
+	for i, v := range w.CTEs {
+		if v.Name == "" {
+			return "", nil, &BuildError{Builder: "With", Clause: "CTEs" + index(i), Err: ErrInvalidIdent}
+		}
+		q, a, err := clause(d, "With", v.Name, v.Query)
+		if err != nil {
+			return "", nil, err
+		}
+		if i != 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(v.Name)
+		if len(v.Columns) > 0 {
+			b.WriteString("(" + strings.Join(v.Columns, ",") + ")")
+		}
+		b.WriteString(" AS (" + q + ")")
+		args = append(args, a...)
+	}
+
+	q, a, err := clause(d, "With", "Query", w.Query)
+	if err != nil {
+		return "", nil, err
+	}
+	b.WriteString(" " + q)
+	args = append(args, a...)
+
+	return b.String(), args, nil
+}
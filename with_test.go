@@ -0,0 +1,111 @@
+package bsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWith(t *testing.T) {
+	type outStruct struct {
+		cond string
+		vals []interface{}
+	}
+
+	tree := CTE{
+		Name:    "tree",
+		Columns: []string{"id", "parent_id", "depth"},
+		Query: UnionAll{
+			{Fields: []string{"id", "parent_id", "0"}, Table: Raw("org"), Where: EQ("id", 1)},
+			{
+				Fields: []string{"o.id", "o.parent_id", "t.depth+1"},
+				Table:  MakeJoin(InnerJoin, Raw("org o"), Raw("tree t"), Raw("o.parent_id = t.id")),
+				Where:  LT("t.depth", 5),
+			},
+		},
+	}
+
+	var data = []struct {
+		d   Dialect
+		in  Builder
+		out outStruct
+	}{
+		{
+			PostgreSQL,
+			With{
+				Recursive: true,
+				CTEs:      []CTE{tree},
+				Query:     Select{Table: Raw("tree"), Where: GT("depth", 2)},
+			},
+			outStruct{
+				cond: "WITH RECURSIVE tree(id,parent_id,depth) AS (SELECT id,parent_id,0 FROM org WHERE id = $1 UNION ALL SELECT o.id,o.parent_id,t.depth+1 FROM org o JOIN tree t ON o.parent_id = t.id WHERE t.depth < $2) SELECT * FROM tree WHERE depth > $3",
+				vals: []interface{}{1, 5, 2},
+			},
+		},
+		{
+			SQLServer,
+			With{
+				Recursive: true,
+				CTEs:      []CTE{tree},
+				Query:     Delete{Table: Raw("org"), Where: Raw("id IN (SELECT id FROM tree WHERE depth > ?)", 3)},
+			},
+			outStruct{
+				cond: "WITH tree(id,parent_id,depth) AS (SELECT id,parent_id,0 FROM org WHERE id = @p1 UNION ALL SELECT o.id,o.parent_id,t.depth+1 FROM org o JOIN tree t ON o.parent_id = t.id WHERE t.depth < @p2) DELETE FROM org WHERE id IN (SELECT id FROM tree WHERE depth > @p3)",
+				vals: []interface{}{1, 5, 3},
+			},
+		},
+		{
+			MySQL,
+			With{
+				CTEs: []CTE{
+					{Name: "a", Query: Select{Fields: []string{"id"}, Table: Raw("t1"), Where: EQ("x", 1)}},
+					{Name: "b", Query: Select{Fields: []string{"id"}, Table: Raw("t2"), Where: EQ("y", 2)}},
+				},
+				Query: Update{Table: Raw("t3"), Set: Raw("z = ?", 3), Where: Raw("id IN (SELECT id FROM a) OR id IN (SELECT id FROM b)")},
+			},
+			outStruct{
+				cond: "WITH a AS (SELECT id FROM t1 WHERE x = ?),b AS (SELECT id FROM t2 WHERE y = ?) UPDATE t3 SET z = ? WHERE id IN (SELECT id FROM a) OR id IN (SELECT id FROM b)",
+				vals: []interface{}{1, 2, 3},
+			},
+		},
+		{
+			MySQL,
+			Insert{
+				Table: Raw("t3 (id)"),
+				Value: With{
+					CTEs:  []CTE{{Name: "a", Query: Select{Fields: []string{"id"}, Table: Raw("t1"), Where: EQ("x", 1)}}},
+					Query: Select{Table: Raw("a")},
+				},
+			},
+			outStruct{
+				cond: "INSERT INTO t3 (id) WITH a AS (SELECT id FROM t1 WHERE x = ?) SELECT * FROM a",
+				vals: []interface{}{1},
+			},
+		},
+		{
+			SQLite,
+			With{
+				CTEs:  []CTE{{Name: "a", Query: Raw("VALUES (?),(?)", 1, 2)}},
+				Query: Insert{Table: Raw("t"), Value: Raw("SELECT * FROM a WHERE column1 > ?", 0)},
+			},
+			outStruct{
+				cond: "WITH a AS (VALUES (?),(?)) INSERT INTO t SELECT * FROM a WHERE column1 > ?",
+				vals: []interface{}{1, 2, 0},
+			},
+		},
+	}
+
+	ass := assert.New(t)
+	for _, tc := range data {
+		q, a := Build(tc.d, tc.in)
+		ass.Equal(tc.out.cond, q)
+		ass.Equal(tc.out.vals, a)
+	}
+
+	_, _, err := BuildE(MySQL, With{CTEs: []CTE{tree}, Query: Insert{Table: Raw("t"), Value: Raw("SELECT 1")}})
+	ass.True(errors.Is(err, ErrUnsupported))
+	ass.True(errors.Is(Validate(MySQL, With{Query: Select{Table: Raw("t")}}), ErrNilBuilder))
+	ass.True(errors.Is(Validate(MySQL, With{CTEs: []CTE{{Query: Raw("SELECT 1")}}, Query: Select{Table: Raw("t")}}), ErrInvalidIdent))
+	ass.EqualError(Validate(MySQL, With{CTEs: []CTE{{Name: "a"}}, Query: Select{Table: Raw("t")}}), "bsql: With.a: builder is nil")
+}